@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Ikey168/Modulo/services/audit-collector/sdk"
+)
+
+// HandleDebugForwarders reports each forwarder's redacted endpoint, queue
+// depth, in-flight batch size, and last success/failure for live
+// troubleshooting.
+func HandleDebugForwarders(collector *sdk.AuditCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		statuses := collector.ForwarderStatuses()
+		out := make([]gin.H, 0, len(statuses))
+		for _, s := range statuses {
+			out = append(out, gin.H{
+				"name":                 s.Name,
+				"endpoint":             sdk.RedactEndpoint(s.Endpoint),
+				"queue_depth":          s.QueueDepth,
+				"in_flight_batch_size": s.InFlightBatchSize,
+				"last_success":         s.LastSuccess,
+				"last_failure":         s.LastFailure,
+				"last_error":           s.LastError,
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"forwarders": out})
+	}
+}
+
+// HandleDebugRecent returns the last N processed decision logs, optionally
+// filtered by tenant and decision (allow/deny). Fields named in the config's
+// pii_hashing.hash_fields are already hashed by the time an entry lands in
+// the ring buffer; anything not listed there is exposed as-is, so this
+// endpoint should sit behind the same access control as the rest of /debug.
+func HandleDebugRecent(collector *sdk.AuditCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 100
+		if v := c.Query("limit"); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				limit = n
+			}
+		}
+
+		filter := sdk.RecentDecisionsFilter{
+			Tenant:   c.Query("tenant"),
+			Decision: c.Query("decision"),
+			Limit:    limit,
+		}
+
+		c.JSON(http.StatusOK, gin.H{"decisions": collector.RecentDecisions(filter)})
+	}
+}
+
+// HandleDebugPolicies returns aggregated decision counts per
+// policy_id/policy_version observed in the last hour.
+func HandleDebugPolicies(collector *sdk.AuditCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"policies": collector.PolicyStats()})
+	}
+}
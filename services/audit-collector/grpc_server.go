@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"runtime/debug"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditv1 "github.com/Ikey168/Modulo/services/audit-collector/proto/audit/v1"
+	"github.com/Ikey168/Modulo/services/audit-collector/sdk"
+)
+
+// grpcHandledDuration records how long each gRPC method took to handle, labeled by
+// the status code it returned, mirroring the HTTP ingestion path's Prometheus
+// instrumentation.
+var grpcHandledDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "grpc_server_handled_duration_ms",
+		Help:    "Time taken to handle a gRPC request, by method and status code",
+		Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	},
+	[]string{"method", "code"},
+)
+
+func init() {
+	prometheus.MustRegister(grpcHandledDuration)
+}
+
+// decisionLogGRPCServer implements auditv1.DecisionLogServiceServer on top of the
+// same sdk.AuditCollector pipeline the HTTP handler uses.
+type decisionLogGRPCServer struct {
+	auditv1.UnimplementedDecisionLogServiceServer
+	collector *sdk.AuditCollector
+}
+
+// Submit handles a single batch pushed over the unary RPC.
+func (s *decisionLogGRPCServer) Submit(ctx context.Context, batch *auditv1.DecisionLogBatch) (*auditv1.SubmitAck, error) {
+	for _, pbLog := range batch.GetLogs() {
+		ack := s.processOne(ctx, pbLog)
+		if !ack.Accepted {
+			// The unary RPC acks the whole batch at once; surface the first
+			// failure rather than silently dropping it.
+			return ack, nil
+		}
+	}
+	return &auditv1.SubmitAck{Accepted: true}, nil
+}
+
+// SubmitStream accepts decision logs one at a time and acks each in turn.
+func (s *decisionLogGRPCServer) SubmitStream(stream auditv1.DecisionLogService_SubmitStreamServer) error {
+	ctx := stream.Context()
+
+	for {
+		pbLog, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(s.processOne(ctx, pbLog)); err != nil {
+			return err
+		}
+	}
+}
+
+// processOne converts a gRPC DecisionLog into the sdk's internal type and runs it
+// through the same validate/enrich/metrics/alert/forward pipeline as the HTTP
+// handler.
+func (s *decisionLogGRPCServer) processOne(ctx context.Context, pbLog *auditv1.DecisionLog) *auditv1.SubmitAck {
+	decisionLog, err := fromProtoDecisionLog(pbLog)
+	if err != nil {
+		return &auditv1.SubmitAck{DecisionId: pbLog.GetDecisionId(), Accepted: false, Error: err.Error()}
+	}
+
+	processed, err := s.collector.ProcessStructured(ctx, decisionLog)
+	if errors.Is(err, sdk.ErrQueueSaturated) {
+		return &auditv1.SubmitAck{DecisionId: decisionLog.DecisionID, Accepted: false, Error: "audit queue saturated"}
+	}
+	if err != nil {
+		return &auditv1.SubmitAck{DecisionId: decisionLog.DecisionID, Accepted: false, Error: err.Error()}
+	}
+
+	return &auditv1.SubmitAck{DecisionId: processed.DecisionID, Accepted: true}
+}
+
+// fromProtoDecisionLog converts the wire message into sdk.DecisionLog, the gRPC
+// analogue of AuditCollector.ExtractDecisionLog for the HTTP path's raw OPA JSON.
+func fromProtoDecisionLog(pbLog *auditv1.DecisionLog) (sdk.DecisionLog, error) {
+	if pbLog.GetDecisionId() == "" {
+		return sdk.DecisionLog{}, fmt.Errorf("missing decision_id")
+	}
+
+	var metadata map[string]interface{}
+	if len(pbLog.MetadataJson) > 0 {
+		if err := json.Unmarshal(pbLog.MetadataJson, &metadata); err != nil {
+			return sdk.DecisionLog{}, fmt.Errorf("invalid metadata_json: %w", err)
+		}
+	}
+
+	user := pbLog.GetUser()
+	request := pbLog.GetRequest()
+	decision := pbLog.GetDecision()
+
+	return sdk.DecisionLog{
+		DecisionID:    pbLog.GetDecisionId(),
+		Timestamp:     pbLog.Timestamp,
+		TraceID:       pbLog.TraceId,
+		SpanID:        pbLog.SpanId,
+		RequestID:     pbLog.RequestId,
+		CorrelationID: pbLog.CorrelationId,
+		User: sdk.UserContext{
+			ID:        user.GetId(),
+			Username:  user.GetUsername(),
+			Email:     user.GetEmail(),
+			Tenant:    user.GetTenant(),
+			Roles:     user.GetRoles(),
+			SessionID: user.GetSessionId(),
+		},
+		Request: sdk.RequestContext{
+			Method:       request.GetMethod(),
+			Path:         request.GetPath(),
+			ResourceType: request.GetResourceType(),
+			ResourceID:   request.GetResourceId(),
+			Action:       request.GetAction(),
+			Workspace:    request.GetWorkspace(),
+			SourceIP:     request.GetSourceIp(),
+		},
+		Decision: sdk.DecisionContext{
+			Allow:            decision.GetAllow(),
+			PolicyID:         decision.GetPolicyId(),
+			PolicyVersion:    decision.GetPolicyVersion(),
+			Rule:             decision.GetRule(),
+			Reason:           decision.GetReason(),
+			EvaluationTimeMS: decision.GetEvaluationTimeMs(),
+			TokenValid:       decision.GetTokenValid(),
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+// recoveryUnaryInterceptor turns a panic in a unary handler into a codes.Internal
+// error instead of crashing the process, matching gin.Recovery() on the HTTP side.
+func recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in gRPC handler %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Errorf(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoveryStreamInterceptor is the streaming counterpart of recoveryUnaryInterceptor.
+func recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in gRPC stream handler %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Errorf(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// metricsUnaryInterceptor records grpc_server_handled_duration_ms for unary calls.
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	grpcHandledDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(float64(time.Since(start).Milliseconds()))
+	return resp, err
+}
+
+// metricsStreamInterceptor records grpc_server_handled_duration_ms for the lifetime
+// of a streaming call.
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	grpcHandledDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(float64(time.Since(start).Milliseconds()))
+	return err
+}
+
+// NewGRPCServer builds a gRPC server exposing DecisionLogService, backed by the
+// same sdk.AuditCollector the HTTP handlers use.
+func NewGRPCServer(collector *sdk.AuditCollector) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoveryUnaryInterceptor, metricsUnaryInterceptor),
+		grpc.ChainStreamInterceptor(recoveryStreamInterceptor, metricsStreamInterceptor),
+	)
+	auditv1.RegisterDecisionLogServiceServer(server, &decisionLogGRPCServer{collector: collector})
+	return server
+}
+
+// StartGRPCServer listens on addr and serves DecisionLogService until the process
+// exits or Serve returns an error.
+func StartGRPCServer(addr string, collector *sdk.AuditCollector) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := NewGRPCServer(collector)
+	log.Printf("Starting audit collector gRPC server on %s", addr)
+	return server.Serve(listener)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	auditv1 "github.com/Ikey168/Modulo/services/audit-collector/proto/audit/v1"
+	"github.com/Ikey168/Modulo/services/audit-collector/sdk"
+)
+
+const submitStreamTestDecisionCount = 10000
+
+// TestSubmitStreamForwardsAllDecisions pushes 10k decisions over a single
+// SubmitStream RPC and asserts every one of them lands on the configured
+// forwarder. It's the integration test the gRPC ingestion path has been
+// missing: it would have caught hand-written message structs that gRPC's
+// default codec couldn't actually serialize.
+func TestSubmitStreamForwardsAllDecisions(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "decisions.ndjson")
+	cfgPath := filepath.Join(dir, "config.yaml")
+
+	cfg := fmt.Sprintf(`
+forwarders:
+  - type: file
+    path: %s
+batch_size: 1000
+queue:
+  size: 20000
+  batch_flush_interval: 20ms
+`, outPath)
+	if err := os.WriteFile(cfgPath, []byte(cfg), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	collector, err := sdk.NewFromConfig(cfgPath)
+	if err != nil {
+		t.Fatalf("NewFromConfig: %v", err)
+	}
+
+	const bufSize = 1024 * 1024
+	lis := bufconn.Listen(bufSize)
+	server := NewGRPCServer(collector)
+	go func() {
+		if err := server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			t.Logf("grpc server exited: %v", err)
+		}
+	}()
+	defer server.Stop()
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer dialCancel()
+	conn, err := grpc.DialContext(dialCtx, "bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		t.Fatalf("grpc.DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	client := auditv1.NewDecisionLogServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream, err := client.SubmitStream(ctx)
+	if err != nil {
+		t.Fatalf("SubmitStream: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < submitStreamTestDecisionCount; i++ {
+			ack, err := stream.Recv()
+			if err != nil {
+				done <- fmt.Errorf("Recv at ack %d: %w", i, err)
+				return
+			}
+			if !ack.Accepted {
+				done <- fmt.Errorf("decision %d rejected: %s", i, ack.Error)
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	for i := 0; i < submitStreamTestDecisionCount; i++ {
+		log := &auditv1.DecisionLog{
+			DecisionId: fmt.Sprintf("decision-%d", i),
+			Timestamp:  time.Now().Unix(),
+			User:       &auditv1.UserContext{Id: "user-1", Tenant: "acme"},
+			Request:    &auditv1.RequestContext{Method: "GET", Path: "/widgets", ResourceType: "widget", Action: "read"},
+			Decision:   &auditv1.DecisionContext{Allow: true, PolicyId: "widgets.rego"},
+		}
+		if err := stream.Send(log); err != nil {
+			t.Fatalf("Send at decision %d: %v", i, err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("streaming %d decisions: %v", submitStreamTestDecisionCount, err)
+	}
+
+	forwarded := waitForLineCount(t, outPath, submitStreamTestDecisionCount, 10*time.Second)
+	if forwarded != submitStreamTestDecisionCount {
+		t.Fatalf("forwarder file has %d lines, want %d", forwarded, submitStreamTestDecisionCount)
+	}
+}
+
+// waitForLineCount polls path until it has at least want lines (the file
+// forwarder's queue flushes asynchronously in the background), or fails the
+// test once timeout elapses.
+func waitForLineCount(t *testing.T, path string, want int, timeout time.Duration) int {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	var lines int
+	for time.Now().Before(deadline) {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("reading %s: %v", path, err)
+		}
+		lines = strings.Count(string(data), "\n")
+		if lines >= want {
+			return lines
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return lines
+}
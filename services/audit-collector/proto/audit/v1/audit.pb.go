@@ -0,0 +1,301 @@
+// Hand-written to mirror proto/audit/v1/audit.proto. These structs do not
+// implement proto.Message; they are serialized by the JSON codec in codec.go.
+
+package auditv1
+
+// DecisionLogBatch is the unary request message for DecisionLogService.Submit.
+type DecisionLogBatch struct {
+	Logs []*DecisionLog `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (m *DecisionLogBatch) GetLogs() []*DecisionLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+// DecisionLog is the gRPC wire representation of an OPA decision log entry. It
+// mirrors the JSON DecisionLog used by the HTTP ingestion path, with metadata
+// carried as an opaque JSON blob so the schema doesn't have to track the
+// collector's loosely-typed map[string]interface{}.
+type DecisionLog struct {
+	DecisionId    string           `protobuf:"bytes,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	Timestamp     int64            `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TraceId       string           `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId        string           `protobuf:"bytes,4,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	RequestId     string           `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CorrelationId string           `protobuf:"bytes,6,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	User          *UserContext     `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`
+	Request       *RequestContext  `protobuf:"bytes,8,opt,name=request,proto3" json:"request,omitempty"`
+	Decision      *DecisionContext `protobuf:"bytes,9,opt,name=decision,proto3" json:"decision,omitempty"`
+	MetadataJson  []byte           `protobuf:"bytes,10,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
+}
+
+func (m *DecisionLog) GetDecisionId() string {
+	if m != nil {
+		return m.DecisionId
+	}
+	return ""
+}
+
+func (m *DecisionLog) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *DecisionLog) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+func (m *DecisionLog) GetSpanId() string {
+	if m != nil {
+		return m.SpanId
+	}
+	return ""
+}
+
+func (m *DecisionLog) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *DecisionLog) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *DecisionLog) GetUser() *UserContext {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *DecisionLog) GetRequest() *RequestContext {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *DecisionLog) GetDecision() *DecisionContext {
+	if m != nil {
+		return m.Decision
+	}
+	return nil
+}
+
+func (m *DecisionLog) GetMetadataJson() []byte {
+	if m != nil {
+		return m.MetadataJson
+	}
+	return nil
+}
+
+type UserContext struct {
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username  string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email     string   `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Tenant    string   `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Roles     []string `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	SessionId string   `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (m *UserContext) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UserContext) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *UserContext) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *UserContext) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *UserContext) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *UserContext) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type RequestContext struct {
+	Method       string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Path         string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	ResourceType string `protobuf:"bytes,3,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId   string `protobuf:"bytes,4,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Action       string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Workspace    string `protobuf:"bytes,6,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	SourceIp     string `protobuf:"bytes,7,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+}
+
+func (m *RequestContext) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *RequestContext) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *RequestContext) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *RequestContext) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *RequestContext) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *RequestContext) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
+func (m *RequestContext) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+type DecisionContext struct {
+	Allow            bool    `protobuf:"varint,1,opt,name=allow,proto3" json:"allow,omitempty"`
+	PolicyId         string  `protobuf:"bytes,2,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	PolicyVersion    string  `protobuf:"bytes,3,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	Rule             string  `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	Reason           string  `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	EvaluationTimeMs float64 `protobuf:"fixed64,6,opt,name=evaluation_time_ms,json=evaluationTimeMs,proto3" json:"evaluation_time_ms,omitempty"`
+	TokenValid       bool    `protobuf:"varint,7,opt,name=token_valid,json=tokenValid,proto3" json:"token_valid,omitempty"`
+}
+
+func (m *DecisionContext) GetAllow() bool {
+	if m != nil {
+		return m.Allow
+	}
+	return false
+}
+
+func (m *DecisionContext) GetPolicyId() string {
+	if m != nil {
+		return m.PolicyId
+	}
+	return ""
+}
+
+func (m *DecisionContext) GetPolicyVersion() string {
+	if m != nil {
+		return m.PolicyVersion
+	}
+	return ""
+}
+
+func (m *DecisionContext) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *DecisionContext) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DecisionContext) GetEvaluationTimeMs() float64 {
+	if m != nil {
+		return m.EvaluationTimeMs
+	}
+	return 0
+}
+
+func (m *DecisionContext) GetTokenValid() bool {
+	if m != nil {
+		return m.TokenValid
+	}
+	return false
+}
+
+// SubmitAck acknowledges decision logs: SubmitStream returns one per message,
+// Submit returns one for the whole batch.
+type SubmitAck struct {
+	DecisionId string `protobuf:"bytes,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	Accepted   bool   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Error      string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SubmitAck) GetDecisionId() string {
+	if m != nil {
+		return m.DecisionId
+	}
+	return ""
+}
+
+func (m *SubmitAck) GetAccepted() bool {
+	if m != nil {
+		return m.Accepted
+	}
+	return false
+}
+
+func (m *SubmitAck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
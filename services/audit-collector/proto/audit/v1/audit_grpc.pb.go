@@ -0,0 +1,158 @@
+// Hand-written to mirror proto/audit/v1/audit.proto. The client stubs opt into
+// the JSON codec from codec.go via grpc.CallContentSubtype.
+
+package auditv1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DecisionLogServiceClient is the client API for DecisionLogService.
+type DecisionLogServiceClient interface {
+	Submit(ctx context.Context, in *DecisionLogBatch, opts ...grpc.CallOption) (*SubmitAck, error)
+	SubmitStream(ctx context.Context, opts ...grpc.CallOption) (DecisionLogService_SubmitStreamClient, error)
+}
+
+type decisionLogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewDecisionLogServiceClient builds a DecisionLogServiceClient on top of cc.
+func NewDecisionLogServiceClient(cc grpc.ClientConnInterface) DecisionLogServiceClient {
+	return &decisionLogServiceClient{cc}
+}
+
+func (c *decisionLogServiceClient) Submit(ctx context.Context, in *DecisionLogBatch, opts ...grpc.CallOption) (*SubmitAck, error) {
+	out := new(SubmitAck)
+	opts = append(opts, grpc.CallContentSubtype(codecName))
+	if err := c.cc.Invoke(ctx, "/audit.v1.DecisionLogService/Submit", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionLogServiceClient) SubmitStream(ctx context.Context, opts ...grpc.CallOption) (DecisionLogService_SubmitStreamClient, error) {
+	opts = append(opts, grpc.CallContentSubtype(codecName))
+	stream, err := c.cc.NewStream(ctx, &_DecisionLogService_serviceDesc.Streams[0], "/audit.v1.DecisionLogService/SubmitStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &decisionLogServiceSubmitStreamClient{stream}, nil
+}
+
+// DecisionLogService_SubmitStreamClient is the client-side handle for the
+// bidirectional SubmitStream RPC.
+type DecisionLogService_SubmitStreamClient interface {
+	Send(*DecisionLog) error
+	Recv() (*SubmitAck, error)
+	grpc.ClientStream
+}
+
+type decisionLogServiceSubmitStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *decisionLogServiceSubmitStreamClient) Send(m *DecisionLog) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *decisionLogServiceSubmitStreamClient) Recv() (*SubmitAck, error) {
+	m := new(SubmitAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DecisionLogServiceServer is the server API for DecisionLogService.
+type DecisionLogServiceServer interface {
+	Submit(context.Context, *DecisionLogBatch) (*SubmitAck, error)
+	SubmitStream(DecisionLogService_SubmitStreamServer) error
+}
+
+// UnimplementedDecisionLogServiceServer can be embedded to have forward compatible
+// implementations.
+type UnimplementedDecisionLogServiceServer struct{}
+
+func (UnimplementedDecisionLogServiceServer) Submit(context.Context, *DecisionLogBatch) (*SubmitAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+
+func (UnimplementedDecisionLogServiceServer) SubmitStream(DecisionLogService_SubmitStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubmitStream not implemented")
+}
+
+// DecisionLogService_SubmitStreamServer is the server-side handle for the
+// bidirectional SubmitStream RPC.
+type DecisionLogService_SubmitStreamServer interface {
+	Send(*SubmitAck) error
+	Recv() (*DecisionLog, error)
+	grpc.ServerStream
+}
+
+// RegisterDecisionLogServiceServer registers srv with s.
+func RegisterDecisionLogServiceServer(s *grpc.Server, srv DecisionLogServiceServer) {
+	s.RegisterService(&_DecisionLogService_serviceDesc, srv)
+}
+
+var _DecisionLogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "audit.v1.DecisionLogService",
+	HandlerType: (*DecisionLogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Submit",
+			Handler:    _DecisionLogService_Submit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitStream",
+			Handler:       _DecisionLogService_SubmitStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/audit/v1/audit.proto",
+}
+
+func _DecisionLogService_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecisionLogBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionLogServiceServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.DecisionLogService/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionLogServiceServer).Submit(ctx, req.(*DecisionLogBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionLogService_SubmitStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DecisionLogServiceServer).SubmitStream(&decisionLogServiceSubmitStreamServer{stream})
+}
+
+type decisionLogServiceSubmitStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *decisionLogServiceSubmitStreamServer) Send(m *SubmitAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *decisionLogServiceSubmitStreamServer) Recv() (*DecisionLog, error) {
+	m := new(DecisionLog)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,45 @@
+package auditv1
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype under which jsonCodec is registered.
+// It is deliberately not "proto" (gRPC's default subtype): registering under
+// the default name would silently replace the codec every other proto-based
+// client/server in the process uses, including real protobuf peers. Callers
+// that want jsonCodec must opt in explicitly via grpc.CallContentSubtype, as
+// the client stubs in audit_grpc.pb.go do.
+const codecName = "auditjson"
+
+// jsonCodec implements encoding.Codec for this package's hand-written message
+// structs. They carry `protobuf:"..."` struct tags as documentation of the
+// wire schema, but none of them implement proto.Message (Reset/String/
+// ProtoReflect) — generating that requires protoc-gen-go against audit.proto,
+// and no protoc toolchain is available to run here. Until the real stubs are
+// generated, this codec lets callers that opt into the "auditjson" content-
+// subtype serialize these messages as JSON instead of the wire format
+// protobuf would use. It's wire-compatible only with other clients/servers
+// built from this same package.
+//
+// Once real stubs are generated from audit.proto with protoc-gen-go, drop this
+// file: the generated *.pb.go will implement proto.Message themselves.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,376 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+// Alerting metrics
+var (
+	alertsFiredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alerts_fired_total",
+			Help: "Total number of alerts fired by the AlertEvaluator",
+		},
+		[]string{"alertname", "tenant"},
+	)
+
+	alertEvaluationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "alert_evaluation_duration_ms",
+			Help:    "Time taken to evaluate a single PromQL alert rule",
+			Buckets: []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500},
+		},
+		[]string{"alertname"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(alertsFiredTotal)
+	prometheus.MustRegister(alertEvaluationDuration)
+}
+
+// AlertForwarder delivers a fired/resolved alert to an external sink (Alertmanager,
+// Slack, PagerDuty, ...). Mirrors LogForwarder so new sinks can be bolted on the
+// same way new log destinations are.
+type AlertForwarder interface {
+	SendAlerts(ctx context.Context, alerts []AlertmanagerAlert) error
+	GetName() string
+}
+
+// AlertmanagerAlert is a single entry of the Alertmanager v2 `/api/v2/alerts` payload.
+type AlertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt,omitempty"`
+	EndsAt      time.Time         `json:"endsAt,omitempty"`
+}
+
+// AlertmanagerForwarder posts alerts to an Alertmanager v2 API endpoint.
+type AlertmanagerForwarder struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewAlertmanagerForwarder builds a forwarder targeting the given Alertmanager base URL.
+func NewAlertmanagerForwarder(endpoint string) *AlertmanagerForwarder {
+	return &AlertmanagerForwarder{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (a *AlertmanagerForwarder) GetName() string {
+	return "alertmanager"
+}
+
+func (a *AlertmanagerForwarder) SendAlerts(ctx context.Context, alerts []AlertmanagerAlert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alertmanager payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", a.endpoint+"/api/v2/alerts", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("alertmanager returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// AlertRule is a named PromQL expression evaluated on an interval and compared
+// against AlertConfig.DenialRateThreshold.
+type AlertRule struct {
+	Name  string
+	Query string
+}
+
+// defaultAlertRules returns the denial-rate SLO expressions evaluated out of the box.
+func defaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{
+			Name:  "HighDenialRate",
+			Query: `sum(rate(opa_denied_requests_total[5m])) by (tenant,resource_type) / sum(rate(opa_decisions_total[5m])) by (tenant,resource_type)`,
+		},
+	}
+}
+
+// groupState tracks the hysteresis window for a single tenant+resource_type group
+// of a single rule.
+type groupState struct {
+	consecutiveAbove int
+	consecutiveBelow int
+	firing           bool
+	observedRate     float64
+}
+
+// AlertEvaluator periodically queries Prometheus for denial-rate SLO violations and
+// forwards them to the configured AlertForwarders once they've been above threshold
+// for ConsecutiveToFire evaluations in a row, resolving after ConsecutiveToResolve
+// evaluations back below threshold.
+type AlertEvaluator struct {
+	promAPI    promv1.API
+	rules      []AlertRule
+	forwarders []AlertForwarder
+	interval   time.Duration
+
+	mu          sync.Mutex
+	config      AlertConfig
+	groups      map[string]map[string]*groupState // rule name -> group key -> state
+	recentUsers map[string]map[string]struct{}    // tenant/resource_type -> usernames denied since the last evaluation
+}
+
+// NewAlertEvaluator builds an evaluator that queries promEndpoint for the rules in
+// cfg and fans violations out to forwarders.
+func NewAlertEvaluator(promEndpoint string, cfg AlertConfig, forwarders []AlertForwarder) (*AlertEvaluator, error) {
+	client, err := api.NewClient(api.Config{Address: promEndpoint})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+
+	interval := cfg.EvaluationInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	return &AlertEvaluator{
+		promAPI:     promv1.NewAPI(client),
+		rules:       defaultAlertRules(),
+		config:      cfg,
+		forwarders:  forwarders,
+		interval:    interval,
+		groups:      make(map[string]map[string]*groupState),
+		recentUsers: make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// UpdateConfig swaps in cfg's thresholds (DenialRateThreshold,
+// ConsecutiveToFire/ToResolve, HighPrivilegeActions, ...) for the next
+// evaluation tick, so a config reload's AlertThresholds take effect without
+// restarting the evaluator or losing its in-flight groupState/recentUsers. The
+// evaluation interval itself is fixed for the lifetime of an evaluator; a
+// changed EvaluationInterval (like a changed Prometheus/Alertmanager endpoint)
+// requires the caller to start a new evaluator instead.
+func (ae *AlertEvaluator) UpdateConfig(cfg AlertConfig) {
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+	ae.config = cfg
+}
+
+// RecordDenial tracks a denied decision's username against its tenant and
+// resource_type, so a rule firing for that group can report who was actually
+// involved rather than just the observed rate. The set is cleared every
+// evaluation tick (see evaluateRule), so an annotation reflects denials since
+// the last evaluation, not every denial for the lifetime of the process.
+func (ae *AlertEvaluator) RecordDenial(tenant, resourceType, username string) {
+	if username == "" {
+		return
+	}
+	key := tenant + "/" + resourceType
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	users, ok := ae.recentUsers[key]
+	if !ok {
+		users = make(map[string]struct{})
+		ae.recentUsers[key] = users
+	}
+	users[username] = struct{}{}
+}
+
+// takeRecentUsersLocked returns the usernames RecordDenial has collected for
+// key since the last call, clearing them for the next batch. Must be called
+// with ae.mu held.
+func (ae *AlertEvaluator) takeRecentUsersLocked(key string) []string {
+	users := ae.recentUsers[key]
+	if len(users) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(users))
+	for u := range users {
+		out = append(out, u)
+	}
+	sort.Strings(out)
+	delete(ae.recentUsers, key)
+	return out
+}
+
+// Run evaluates every configured rule on a ticker until ctx is cancelled.
+func (ae *AlertEvaluator) Run(ctx context.Context) {
+	ticker := time.NewTicker(ae.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ae.evaluateAll(ctx)
+		}
+	}
+}
+
+func (ae *AlertEvaluator) evaluateAll(ctx context.Context) {
+	for _, rule := range ae.rules {
+		ae.evaluateRule(ctx, rule)
+	}
+}
+
+func (ae *AlertEvaluator) evaluateRule(ctx context.Context, rule AlertRule) {
+	start := time.Now()
+	defer func() {
+		alertEvaluationDuration.WithLabelValues(rule.Name).Observe(float64(time.Since(start).Milliseconds()))
+	}()
+
+	result, warnings, err := ae.promAPI.Query(ctx, rule.Query, time.Now())
+	if err != nil {
+		log.Printf("AlertEvaluator: query %q failed: %v", rule.Name, err)
+		return
+	}
+	if len(warnings) > 0 {
+		log.Printf("AlertEvaluator: query %q returned warnings: %v", rule.Name, warnings)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		log.Printf("AlertEvaluator: query %q did not return an instant vector", rule.Name)
+		return
+	}
+
+	ae.mu.Lock()
+	groups, ok := ae.groups[rule.Name]
+	if !ok {
+		groups = make(map[string]*groupState)
+		ae.groups[rule.Name] = groups
+	}
+
+	var toFire, toResolve []AlertmanagerAlert
+
+	for _, sample := range vector {
+		tenant := string(sample.Metric["tenant"])
+		resourceType := string(sample.Metric["resource_type"])
+		key := tenant + "/" + resourceType
+
+		state, ok := groups[key]
+		if !ok {
+			state = &groupState{}
+			groups[key] = state
+		}
+		state.observedRate = float64(sample.Value)
+
+		if state.observedRate >= ae.config.DenialRateThreshold {
+			state.consecutiveAbove++
+			state.consecutiveBelow = 0
+		} else {
+			state.consecutiveBelow++
+			state.consecutiveAbove = 0
+		}
+
+		users := ae.takeRecentUsersLocked(key)
+
+		if !state.firing && state.consecutiveAbove >= ae.config.ConsecutiveToFire {
+			state.firing = true
+			toFire = append(toFire, ae.buildAlert(rule.Name, tenant, resourceType, state.observedRate, users))
+		} else if state.firing && state.consecutiveBelow >= ae.config.ConsecutiveToResolve {
+			state.firing = false
+			toResolve = append(toResolve, ae.buildResolved(rule.Name, tenant, resourceType))
+		}
+	}
+
+	// The query's result vector only covers groups with a non-empty rate this
+	// tick; a (tenant, resource_type) that RecordDenial has seen but the query
+	// didn't return (empty window, relabelling, ...) would never reach
+	// takeRecentUsersLocked above and would accumulate usernames forever. Drain
+	// every recorded key, not just the ones in vector, so recentUsers never
+	// outlives the groups it was collected for.
+	for key := range ae.recentUsers {
+		ae.takeRecentUsersLocked(key)
+	}
+	ae.mu.Unlock()
+
+	ae.dispatch(ctx, toFire)
+	ae.dispatch(ctx, toResolve)
+}
+
+func (ae *AlertEvaluator) buildAlert(alertname, tenant, resourceType string, rate float64, users []string) AlertmanagerAlert {
+	alertsFiredTotal.WithLabelValues(alertname, tenant).Inc()
+
+	annotations := map[string]string{
+		"observed_rate": fmt.Sprintf("%.4f", rate),
+	}
+	if len(users) > 0 {
+		annotations["involved_users"] = strings.Join(users, ",")
+	}
+
+	return AlertmanagerAlert{
+		Labels: map[string]string{
+			"alertname":     alertname,
+			"tenant":        tenant,
+			"resource_type": resourceType,
+			"severity":      "warning",
+		},
+		Annotations: annotations,
+		StartsAt:    time.Now(),
+	}
+}
+
+func (ae *AlertEvaluator) buildResolved(alertname, tenant, resourceType string) AlertmanagerAlert {
+	return AlertmanagerAlert{
+		Labels: map[string]string{
+			"alertname":     alertname,
+			"tenant":        tenant,
+			"resource_type": resourceType,
+			"severity":      "warning",
+		},
+		EndsAt: time.Now(),
+	}
+}
+
+func (ae *AlertEvaluator) dispatch(ctx context.Context, alerts []AlertmanagerAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+
+	// Deterministic ordering makes the Alertmanager payload (and test fixtures) stable.
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Labels["tenant"] != alerts[j].Labels["tenant"] {
+			return alerts[i].Labels["tenant"] < alerts[j].Labels["tenant"]
+		}
+		return alerts[i].Labels["resource_type"] < alerts[j].Labels["resource_type"]
+	})
+
+	for _, fwd := range ae.forwarders {
+		if err := fwd.SendAlerts(ctx, alerts); err != nil {
+			log.Printf("AlertEvaluator: failed to send alerts via %s: %v", fwd.GetName(), err)
+		}
+	}
+}
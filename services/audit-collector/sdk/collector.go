@@ -0,0 +1,869 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// debugRingSize returns the /debug/recent ring buffer size from
+// DEBUG_RING_SIZE, falling back to defaultRingSize if unset or invalid.
+func debugRingSize() int {
+	v := os.Getenv("DEBUG_RING_SIZE")
+	if v == "" {
+		return defaultRingSize
+	}
+	size, err := strconv.Atoi(v)
+	if err != nil || size <= 0 {
+		return defaultRingSize
+	}
+	return size
+}
+
+// DecisionLog represents an OPA decision log entry with full audit context
+type DecisionLog struct {
+	DecisionID    string                 `json:"decision_id"`
+	Timestamp     int64                  `json:"timestamp"`
+	TraceID       string                 `json:"trace_id"`
+	SpanID        string                 `json:"span_id"`
+	RequestID     string                 `json:"request_id"`
+	CorrelationID string                 `json:"correlation_id"`
+	User          UserContext            `json:"user"`
+	Request       RequestContext         `json:"request"`
+	Decision      DecisionContext        `json:"decision"`
+	Metadata      map[string]interface{} `json:"metadata"`
+}
+
+type UserContext struct {
+	ID        string   `json:"id"`
+	Username  string   `json:"username"`
+	Email     string   `json:"email"` // Pre-hashed for PII protection
+	Tenant    string   `json:"tenant"`
+	Roles     []string `json:"roles"`
+	SessionID string   `json:"session_id"`
+}
+
+type RequestContext struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	Action       string `json:"action"`
+	Workspace    string `json:"workspace"`
+	SourceIP     string `json:"source_ip"`
+}
+
+type DecisionContext struct {
+	Allow            bool    `json:"allow"`
+	PolicyID         string  `json:"policy_id"`
+	PolicyVersion    string  `json:"policy_version"`
+	Rule             string  `json:"rule"`
+	Reason           string  `json:"reason"`
+	EvaluationTimeMS float64 `json:"evaluation_time_ms"`
+	TokenValid       bool    `json:"token_valid"`
+}
+
+// Prometheus metrics for monitoring
+var (
+	decisionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "opa_decisions_total",
+			Help: "Total number of OPA authorization decisions processed",
+		},
+		[]string{"decision", "resource_type", "action", "policy_id", "tenant"},
+	)
+
+	decisionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "opa_decision_duration_ms",
+			Help:    "OPA decision evaluation time in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+		},
+		[]string{"decision", "resource_type", "tenant"},
+	)
+
+	deniedRequestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "opa_denied_requests_total",
+			Help: "Total number of denied authorization requests",
+		},
+		[]string{"resource_type", "action", "rule", "tenant", "reason_category"},
+	)
+
+	auditLogProcessed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "audit_logs_processed_total",
+			Help: "Total number of audit log entries processed",
+		},
+		[]string{"status", "destination"},
+	)
+
+	auditLogForwardingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "audit_log_forwarding_duration_ms",
+			Help: "Time taken to forward audit logs to storage",
+		},
+		[]string{"destination"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(decisionCounter)
+	prometheus.MustRegister(decisionDuration)
+	prometheus.MustRegister(deniedRequestsCounter)
+	prometheus.MustRegister(auditLogProcessed)
+	prometheus.MustRegister(auditLogForwardingDuration)
+}
+
+// LogForwarder interface for different log destinations
+type LogForwarder interface {
+	ForwardLog(ctx context.Context, log DecisionLog) error
+	ForwardBatch(ctx context.Context, logs []DecisionLog) error
+	GetName() string
+	// Endpoint returns where this forwarder sends logs (a URL, broker address, or
+	// file path), for display on /debug/forwarders. Callers must redact it
+	// themselves before exposing it further.
+	Endpoint() string
+}
+
+// LokiForwarder sends logs to Grafana Loki
+type LokiForwarder struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewLokiForwarder(endpoint string, timeout time.Duration, tlsCfg TLSConfig) (*LokiForwarder, error) {
+	client, err := newHTTPClient(tlsCfg, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &LokiForwarder{endpoint: endpoint, client: client}, nil
+}
+
+func (l *LokiForwarder) GetName() string {
+	return "loki"
+}
+
+func (l *LokiForwarder) Endpoint() string {
+	return l.endpoint
+}
+
+func (l *LokiForwarder) ForwardLog(ctx context.Context, log DecisionLog) error {
+	return l.ForwardBatch(ctx, []DecisionLog{log})
+}
+
+func (l *LokiForwarder) ForwardBatch(ctx context.Context, logs []DecisionLog) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Milliseconds()
+		auditLogForwardingDuration.WithLabelValues("loki").Observe(float64(duration))
+	}()
+
+	// Group logs by labels for efficient Loki ingestion
+	streams := make(map[string][]interface{})
+
+	for _, log := range logs {
+		// Create Loki labels
+		labels := fmt.Sprintf(`{service="opa-audit",environment="production",decision="%t",resource_type="%s",action="%s",tenant="%s"}`,
+			log.Decision.Allow, log.Request.ResourceType, log.Request.Action, log.User.Tenant)
+
+		// Convert timestamp to nanoseconds string
+		timestampNs := fmt.Sprintf("%d", log.Timestamp)
+
+		// Create log line with structured JSON
+		logLine, _ := json.Marshal(log)
+
+		// Group by labels
+		if streams[labels] == nil {
+			streams[labels] = make([]interface{}, 0)
+		}
+		streams[labels] = append(streams[labels], []string{timestampNs, string(logLine)})
+	}
+
+	// Build Loki payload
+	lokiPayload := map[string]interface{}{
+		"streams": make([]interface{}, 0, len(streams)),
+	}
+
+	for labels, values := range streams {
+		stream := map[string]interface{}{
+			"stream": parseLabels(labels),
+			"values": values,
+		}
+		lokiPayload["streams"] = append(lokiPayload["streams"].([]interface{}), stream)
+	}
+
+	// Send to Loki
+	body, err := json.Marshal(lokiPayload)
+	if err != nil {
+		auditLogProcessed.WithLabelValues("error", "loki").Inc()
+		return fmt.Errorf("failed to marshal Loki payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", l.endpoint+"/loki/api/v1/push", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		auditLogProcessed.WithLabelValues("error", "loki").Inc()
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		auditLogProcessed.WithLabelValues("error", "loki").Inc()
+		return fmt.Errorf("Loki returned status %d", resp.StatusCode)
+	}
+
+	auditLogProcessed.WithLabelValues("success", "loki").Add(float64(len(logs)))
+	return nil
+}
+
+// ElasticsearchForwarder sends logs to Elasticsearch
+type ElasticsearchForwarder struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewElasticsearchForwarder(endpoint string, timeout time.Duration, tlsCfg TLSConfig) (*ElasticsearchForwarder, error) {
+	client, err := newHTTPClient(tlsCfg, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &ElasticsearchForwarder{endpoint: endpoint, client: client}, nil
+}
+
+func (e *ElasticsearchForwarder) GetName() string {
+	return "elasticsearch"
+}
+
+func (e *ElasticsearchForwarder) Endpoint() string {
+	return e.endpoint
+}
+
+func (e *ElasticsearchForwarder) ForwardLog(ctx context.Context, log DecisionLog) error {
+	return e.ForwardBatch(ctx, []DecisionLog{log})
+}
+
+func (e *ElasticsearchForwarder) ForwardBatch(ctx context.Context, logs []DecisionLog) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Milliseconds()
+		auditLogForwardingDuration.WithLabelValues("elasticsearch").Observe(float64(duration))
+	}()
+
+	// Create bulk request for Elasticsearch
+	var bulkBody bytes.Buffer
+
+	for _, log := range logs {
+		// Index directive
+		indexDate := time.Unix(log.Timestamp/1e9, 0).Format("2006.01.02")
+		indexName := fmt.Sprintf("opa-audit-%s", indexDate)
+
+		indexAction := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index": indexName,
+				"_type":  "_doc",
+				"_id":    log.DecisionID,
+			},
+		}
+
+		actionLine, _ := json.Marshal(indexAction)
+		bulkBody.Write(actionLine)
+		bulkBody.WriteString("\n")
+
+		// Document body
+		docLine, _ := json.Marshal(log)
+		bulkBody.Write(docLine)
+		bulkBody.WriteString("\n")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.endpoint+"/_bulk", &bulkBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		auditLogProcessed.WithLabelValues("error", "elasticsearch").Inc()
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		auditLogProcessed.WithLabelValues("error", "elasticsearch").Inc()
+		return fmt.Errorf("Elasticsearch returned status %d", resp.StatusCode)
+	}
+
+	auditLogProcessed.WithLabelValues("success", "elasticsearch").Add(float64(len(logs)))
+	return nil
+}
+
+// AuditCollector handles audit log collection and forwarding. It is built via
+// NewFromConfig and is transport-agnostic: package main wires it up behind both
+// the HTTP and gRPC servers.
+type AuditCollector struct {
+	mu             sync.RWMutex
+	forwarders     []LogForwarder
+	queues         map[string]*ForwarderQueue
+	config         *Config
+	configPath     string
+	alertEvaluator *AlertEvaluator
+	alertConfig    AlertConfig
+	alertCancel    context.CancelFunc
+	watcher        *Watcher
+
+	recent      *decisionRing
+	recentMu    sync.Mutex
+	policyStats *policyStatsTracker
+}
+
+// buildQueues wraps each forwarder in a ForwarderQueue, matching it up with its
+// own ForwarderConfig (by position) for per-forwarder timeout/retry settings.
+func buildQueues(forwarders []LogForwarder, cfg *Config) (map[string]*ForwarderQueue, error) {
+	queues := make(map[string]*ForwarderQueue, len(forwarders))
+	typeOrdinal := make(map[string]int, len(forwarders))
+
+	for i, forwarder := range forwarders {
+		fcfg := ForwarderConfig{}
+		if i < len(cfg.Forwarders) {
+			fcfg = cfg.Forwarders[i]
+		}
+
+		q, err := NewForwarderQueue(forwarder, perForwarderBatchSize(cfg, fcfg), cfg.Queue, fcfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build queue for forwarder %s: %w", forwarder.GetName(), err)
+		}
+
+		// A config can list more than one forwarder of the same type (two "http"
+		// sinks, say); keying purely by GetName() would collide and silently drop
+		// every queue but the first, leaking its worker goroutine. Only the
+		// second-and-later instance of a type gets a disambiguating suffix, so
+		// the common single-instance-per-type config keeps its plain metric label.
+		name := forwarder.GetName()
+		ordinal := typeOrdinal[name]
+		typeOrdinal[name] = ordinal + 1
+		if ordinal > 0 {
+			name = fmt.Sprintf("%s-%d", name, ordinal)
+		}
+		q.name = name
+
+		queues[name] = q
+	}
+
+	return queues, nil
+}
+
+// perForwarderBatchSize returns fcfg's own BatchSize if it sets one, falling
+// back to the collector-wide default otherwise.
+func perForwarderBatchSize(cfg *Config, fcfg ForwarderConfig) int {
+	if fcfg.BatchSize > 0 {
+		return fcfg.BatchSize
+	}
+	return cfg.BatchSize
+}
+
+// NewFromConfig loads path, builds the configured forwarders and alert evaluator,
+// and starts watching the file for changes so operators can add/remove forwarders
+// or tune thresholds without restarting the process.
+func NewFromConfig(path string) (*AuditCollector, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	forwarders, err := BuildForwarders(cfg.Forwarders)
+	if err != nil {
+		return nil, err
+	}
+
+	queues, err := buildQueues(forwarders, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ac := &AuditCollector{
+		forwarders:  forwarders,
+		queues:      queues,
+		config:      cfg,
+		configPath:  path,
+		recent:      newDecisionRing(debugRingSize()),
+		policyStats: newPolicyStatsTracker(),
+	}
+
+	for _, q := range queues {
+		q.Start(context.Background())
+	}
+
+	ac.mu.Lock()
+	ac.reconcileAlertEvaluator(cfg.AlertThresholds)
+	ac.mu.Unlock()
+
+	watcher, err := NewWatcher(path, ac.reload)
+	if err != nil {
+		log.Printf("AuditCollector: config hot-reload disabled, failed to watch %s: %v", path, err)
+	} else {
+		ac.watcher = watcher
+	}
+
+	return ac, nil
+}
+
+// reconcileAlertEvaluator starts, restarts, or hot-updates ac.alertEvaluator to
+// match cfg. A changed PrometheusEndpoint/AlertmanagerEndpoint needs a new
+// promv1.API client and AlertForwarder, and a changed EvaluationInterval a new
+// ticker, so the old evaluator's Run loop is cancelled and a new one started;
+// everything else (DenialRateThreshold,
+// ConsecutiveToFire/ToResolve, HighPrivilegeActions) is hot-swapped in place on
+// the running evaluator via UpdateConfig so its groupState/recentUsers aren't
+// reset by an unrelated threshold tweak. Must be called with ac.mu held.
+func (ac *AuditCollector) reconcileAlertEvaluator(cfg AlertConfig) {
+	if cfg.PrometheusEndpoint == "" {
+		if ac.alertCancel != nil {
+			ac.alertCancel()
+		}
+		ac.alertEvaluator = nil
+		ac.alertCancel = nil
+		ac.alertConfig = cfg
+		return
+	}
+
+	endpointsChanged := ac.alertEvaluator == nil ||
+		ac.alertConfig.PrometheusEndpoint != cfg.PrometheusEndpoint ||
+		ac.alertConfig.AlertmanagerEndpoint != cfg.AlertmanagerEndpoint ||
+		ac.alertConfig.EvaluationInterval != cfg.EvaluationInterval
+
+	if !endpointsChanged {
+		ac.alertEvaluator.UpdateConfig(cfg)
+		ac.alertConfig = cfg
+		return
+	}
+
+	evaluator, err := NewAlertEvaluator(cfg.PrometheusEndpoint, cfg, []AlertForwarder{NewAlertmanagerForwarder(cfg.AlertmanagerEndpoint)})
+	if err != nil {
+		log.Printf("AuditCollector: failed to (re)initialize alert evaluator: %v", err)
+		return
+	}
+
+	if ac.alertCancel != nil {
+		ac.alertCancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ac.alertEvaluator = evaluator
+	ac.alertCancel = cancel
+	ac.alertConfig = cfg
+	go evaluator.Run(ctx)
+}
+
+// reload re-reads the config file, builds the new forwarder/queue set, and only
+// then swaps it in: the old queues are stopped (which flushes whatever they have
+// buffered to their forwarder) after the swap, so in-flight batches drain on the
+// old forwarder set rather than being silently discarded mid-reload. It also
+// reconciles the alert evaluator against the reloaded AlertThresholds and closes
+// any retired forwarder that holds a resource (e.g. FileForwarder's fd).
+func (ac *AuditCollector) reload(cfg *Config) {
+	forwarders, err := BuildForwarders(cfg.Forwarders)
+	if err != nil {
+		log.Printf("AuditCollector: rejected config reload: %v", err)
+		return
+	}
+
+	queues, err := buildQueues(forwarders, cfg)
+	if err != nil {
+		log.Printf("AuditCollector: rejected config reload: %v", err)
+		return
+	}
+	for _, q := range queues {
+		q.Start(context.Background())
+	}
+
+	ac.mu.Lock()
+	oldQueues := ac.queues
+	oldForwarders := ac.forwarders
+	ac.forwarders = forwarders
+	ac.queues = queues
+	ac.config = cfg
+	ac.reconcileAlertEvaluator(cfg.AlertThresholds)
+	ac.mu.Unlock()
+
+	for _, q := range oldQueues {
+		q.Stop()
+	}
+
+	for _, fwd := range oldForwarders {
+		closer, ok := fwd.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			log.Printf("AuditCollector: failed to close retired forwarder %s: %v", fwd.GetName(), err)
+		}
+	}
+
+	log.Printf("AuditCollector: reloaded config from %s (%d forwarders)", ac.configPath, len(forwarders))
+}
+
+// Forwarders returns the currently active forwarder set.
+func (ac *AuditCollector) Forwarders() []LogForwarder {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	return ac.forwarders
+}
+
+// Config returns the collector's current configuration.
+func (ac *AuditCollector) Config() *Config {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	return ac.config
+}
+
+// HandleBatch runs a batch of raw OPA decision log entries through
+// extract/validate/enrich/metrics/alert and enqueues the survivors onto every
+// forwarder's queue. It returns the logs that made it through extraction and
+// validation, and ErrQueueSaturated if any forwarder's queue rejected an entry
+// under DropPolicy "reject" (the caller should respond 503).
+func (ac *AuditCollector) HandleBatch(ctx context.Context, opaLogs []map[string]interface{}) ([]DecisionLog, error) {
+	processedLogs := make([]DecisionLog, 0, len(opaLogs))
+
+	for _, opaLog := range opaLogs {
+		decisionLog, err := ac.ExtractDecisionLog(opaLog)
+		if err != nil {
+			log.Printf("Failed to extract decision log: %v", err)
+			continue
+		}
+
+		if err := ac.ValidateAndEnrichLog(&decisionLog); err != nil {
+			log.Printf("Invalid decision log: %v", err)
+			continue
+		}
+
+		processedLogs = append(processedLogs, decisionLog)
+
+		ac.RecordMetrics(decisionLog)
+		ac.CheckForAlerts(decisionLog)
+		ac.recordDebug(decisionLog)
+	}
+
+	var saturated error
+	for _, q := range ac.activeQueues() {
+		for _, decisionLog := range processedLogs {
+			if err := q.Enqueue(decisionLog); err != nil {
+				saturated = normalizeEnqueueErr(err)
+			}
+		}
+	}
+
+	return processedLogs, saturated
+}
+
+// normalizeEnqueueErr maps ErrQueueStopped (a queue retired mid-request by a
+// config reload) onto ErrQueueSaturated, since both mean the same thing to a
+// caller: this entry wasn't enqueued, try again.
+func normalizeEnqueueErr(err error) error {
+	if errors.Is(err, ErrQueueStopped) {
+		return ErrQueueSaturated
+	}
+	return err
+}
+
+// ProcessStructured runs an already-structured DecisionLog (e.g. received over
+// gRPC) through validate/enrich/metrics/alert and enqueues it for forwarding. It
+// returns ErrQueueSaturated if any forwarder's queue rejected it.
+func (ac *AuditCollector) ProcessStructured(ctx context.Context, decisionLog DecisionLog) (DecisionLog, error) {
+	if err := ac.ValidateAndEnrichLog(&decisionLog); err != nil {
+		return decisionLog, err
+	}
+
+	ac.RecordMetrics(decisionLog)
+	ac.CheckForAlerts(decisionLog)
+	ac.recordDebug(decisionLog)
+
+	var saturated error
+	for _, q := range ac.activeQueues() {
+		if err := q.Enqueue(decisionLog); err != nil {
+			saturated = normalizeEnqueueErr(err)
+		}
+	}
+
+	return decisionLog, saturated
+}
+
+func (ac *AuditCollector) activeQueues() map[string]*ForwarderQueue {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	return ac.queues
+}
+
+// activeAlertEvaluator returns the currently running alert evaluator, or nil if
+// alerting is disabled (no Prometheus endpoint configured).
+func (ac *AuditCollector) activeAlertEvaluator() *AlertEvaluator {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	return ac.alertEvaluator
+}
+
+// ReplayDLQ re-injects every dead-lettered batch for forwarderName (or every
+// forwarder, if forwarderName is empty) back through ForwardBatch, removing a
+// DLQ segment once it has been fully replayed. Each forwarder's active DLQ
+// segment, the one still being appended to, is not replayed (see DLQ.Replay).
+func (ac *AuditCollector) ReplayDLQ(ctx context.Context, forwarderName string) (int, error) {
+	total := 0
+	for name, q := range ac.activeQueues() {
+		if forwarderName != "" && name != forwarderName {
+			continue
+		}
+
+		n, err := q.dlq.Replay(ctx, ac.Config().BatchSize, q.forwardWithRetry)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// ExtractDecisionLog extracts a structured decision log from a raw OPA log entry.
+func (ac *AuditCollector) ExtractDecisionLog(opaLog map[string]interface{}) (DecisionLog, error) {
+	result, ok := opaLog["result"].(map[string]interface{})
+	if !ok {
+		return DecisionLog{}, fmt.Errorf("missing result in OPA log")
+	}
+
+	audit, ok := result["audit"].(map[string]interface{})
+	if !ok {
+		return DecisionLog{}, fmt.Errorf("missing audit context in OPA result")
+	}
+
+	// Parse timestamp
+	timestamp, _ := audit["timestamp"].(float64)
+	if timestamp == 0 {
+		timestamp = float64(time.Now().UnixNano())
+	}
+
+	// Extract user context
+	userMap, _ := audit["user"].(map[string]interface{})
+	user := UserContext{
+		ID:        getString(userMap, "id"),
+		Username:  getString(userMap, "username"),
+		Email:     getString(userMap, "email"),
+		Tenant:    getString(userMap, "tenant"),
+		Roles:     getStringSlice(userMap, "roles"),
+		SessionID: getString(userMap, "session_id"),
+	}
+
+	// Extract request context
+	requestMap, _ := audit["request"].(map[string]interface{})
+	request := RequestContext{
+		Method:       getString(requestMap, "method"),
+		Path:         getString(requestMap, "path"),
+		ResourceType: getString(requestMap, "resource_type"),
+		ResourceID:   getString(requestMap, "resource_id"),
+		Action:       getString(requestMap, "action"),
+		Workspace:    getString(requestMap, "workspace"),
+		SourceIP:     getString(requestMap, "source_ip"),
+	}
+
+	// Extract decision context
+	decisionMap, _ := audit["decision"].(map[string]interface{})
+	decision := DecisionContext{
+		Allow:            getBool(decisionMap, "allow"),
+		PolicyID:         getString(decisionMap, "policy_id"),
+		PolicyVersion:    getString(decisionMap, "policy_version"),
+		Rule:             getString(decisionMap, "rule"),
+		Reason:           getString(decisionMap, "reason"),
+		EvaluationTimeMS: getFloat64(decisionMap, "evaluation_time_ms"),
+		TokenValid:       getBool(decisionMap, "token_valid"),
+	}
+
+	// Extract metadata
+	metadata, _ := audit["metadata"].(map[string]interface{})
+
+	return DecisionLog{
+		DecisionID:    getString(audit, "decision_id"),
+		Timestamp:     int64(timestamp),
+		TraceID:       getString(audit, "trace_id"),
+		SpanID:        getString(audit, "span_id"),
+		RequestID:     getString(audit, "request_id"),
+		CorrelationID: getString(audit, "correlation_id"),
+		User:          user,
+		Request:       request,
+		Decision:      decision,
+		Metadata:      metadata,
+	}, nil
+}
+
+// ValidateAndEnrichLog ensures log integrity and adds enrichment data
+func (ac *AuditCollector) ValidateAndEnrichLog(log *DecisionLog) error {
+	if log.DecisionID == "" {
+		return fmt.Errorf("missing decision_id")
+	}
+
+	if log.User.ID == "" {
+		return fmt.Errorf("missing user.id")
+	}
+
+	if log.Request.ResourceType == "" {
+		return fmt.Errorf("missing request.resource_type")
+	}
+
+	// Ensure timestamp is valid
+	if log.Timestamp == 0 {
+		log.Timestamp = time.Now().UnixNano()
+	}
+
+	// Add enrichment data
+	if log.Metadata == nil {
+		log.Metadata = make(map[string]interface{})
+	}
+	log.Metadata["processed_at"] = time.Now().Unix()
+	log.Metadata["collector_version"] = "1.0.0"
+
+	if cfg := ac.Config(); cfg != nil && len(cfg.PIIHashing.HashFields) > 0 {
+		applyPIIHashing(log, cfg.PIIHashing.HashFields)
+	}
+
+	return nil
+}
+
+// RecordMetrics updates Prometheus metrics
+func (ac *AuditCollector) RecordMetrics(decisionLog DecisionLog) {
+	decision := "allow"
+	if !decisionLog.Decision.Allow {
+		decision = "deny"
+	}
+
+	decisionCounter.WithLabelValues(
+		decision,
+		decisionLog.Request.ResourceType,
+		decisionLog.Request.Action,
+		decisionLog.Decision.PolicyID,
+		decisionLog.User.Tenant,
+	).Inc()
+
+	decisionDuration.WithLabelValues(
+		decision,
+		decisionLog.Request.ResourceType,
+		decisionLog.User.Tenant,
+	).Observe(decisionLog.Decision.EvaluationTimeMS)
+
+	if !decisionLog.Decision.Allow {
+		reasonCategory := categorizeReason(decisionLog.Decision.Reason)
+		deniedRequestsCounter.WithLabelValues(
+			decisionLog.Request.ResourceType,
+			decisionLog.Request.Action,
+			decisionLog.Decision.Rule,
+			decisionLog.User.Tenant,
+			reasonCategory,
+		).Inc()
+	}
+}
+
+// CheckForAlerts analyzes a single log for suspicious patterns. It's the
+// immediate, per-log heuristic; AlertEvaluator handles the Prometheus-backed SLO
+// enforcement on a longer window.
+func (ac *AuditCollector) CheckForAlerts(decisionLog DecisionLog) {
+	if decisionLog.Decision.Allow {
+		return
+	}
+
+	if ae := ac.activeAlertEvaluator(); ae != nil {
+		ae.RecordDenial(decisionLog.User.Tenant, decisionLog.Request.ResourceType, decisionLog.User.Username)
+	}
+
+	thresholds := effectiveAlertConfig(ac.Config(), decisionLog.User.Tenant)
+
+	for _, action := range thresholds.HighPrivilegeActions {
+		if decisionLog.Request.Action == action {
+			log.Printf("ALERT: Denied high-privilege action %s by user %s on %s:%s",
+				action, decisionLog.User.Username, decisionLog.Request.ResourceType, decisionLog.Request.ResourceID)
+		}
+	}
+
+	log.Printf("AUDIT: Authorization denied - User: %s, Action: %s, Resource: %s:%s, Reason: %s",
+		decisionLog.User.Username, decisionLog.Request.Action, decisionLog.Request.ResourceType, decisionLog.Request.ResourceID, decisionLog.Decision.Reason)
+}
+
+// effectiveAlertConfig returns tenant's AlertConfig override from cfg.TenantOverrides
+// if it set one, otherwise cfg's global AlertThresholds.
+func effectiveAlertConfig(cfg *Config, tenant string) AlertConfig {
+	if cfg == nil {
+		return AlertConfig{}
+	}
+	if override, ok := cfg.TenantOverrides[tenant]; ok && override.AlertThresholds != nil {
+		return *override.AlertThresholds
+	}
+	return cfg.AlertThresholds
+}
+
+// Utility functions
+func parseLabels(labelString string) map[string]string {
+	// Simple label parser - in production use proper parser
+	labels := make(map[string]string)
+	// This is simplified - would need proper LogQL parser
+	return labels
+}
+
+func getString(m map[string]interface{}, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func getBool(m map[string]interface{}, key string) bool {
+	if v, ok := m[key].(bool); ok {
+		return v
+	}
+	return false
+}
+
+func getFloat64(m map[string]interface{}, key string) float64 {
+	if v, ok := m[key].(float64); ok {
+		return v
+	}
+	return 0
+}
+
+func getStringSlice(m map[string]interface{}, key string) []string {
+	if v, ok := m[key].([]interface{}); ok {
+		result := make([]string, len(v))
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				result[i] = s
+			}
+		}
+		return result
+	}
+	return []string{}
+}
+
+func categorizeReason(reason string) string {
+	reason = strings.ToLower(reason)
+	if strings.Contains(reason, "insufficient") || strings.Contains(reason, "cannot") {
+		return "insufficient_privileges"
+	} else if strings.Contains(reason, "expired") || strings.Contains(reason, "invalid") {
+		return "invalid_token"
+	} else if strings.Contains(reason, "unknown") {
+		return "unknown_resource"
+	}
+	return "other"
+}
@@ -0,0 +1,205 @@
+// Package sdk is the embeddable core of the audit collector: the decision log
+// pipeline, forwarders, and alert evaluation, independent of any particular
+// transport (HTTP, gRPC, ...). Transports live in package main and call into an
+// *AuditCollector built by NewFromConfig.
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level, file-driven configuration for an AuditCollector. It
+// replaces the old env-var-only bootstrap: a single file describes forwarders,
+// retention, alert thresholds, PII hashing rules, and per-tenant overrides.
+type Config struct {
+	Forwarders      []ForwarderConfig         `json:"forwarders" yaml:"forwarders"`
+	RetentionDays   int                       `json:"retention_days" yaml:"retention_days"`
+	BatchSize       int                       `json:"batch_size" yaml:"batch_size"`
+	AlertThresholds AlertConfig               `json:"alert_thresholds" yaml:"alert_thresholds"`
+	PIIHashing      PIIConfig                 `json:"pii_hashing" yaml:"pii_hashing"`
+	TenantOverrides map[string]TenantOverride `json:"tenant_overrides" yaml:"tenant_overrides"`
+	Queue           QueueConfig               `json:"queue" yaml:"queue"`
+}
+
+// QueueConfig governs the bounded, asynchronous per-forwarder queue that sits
+// between ingestion and ForwardBatch, so a slow or unhealthy forwarder doesn't
+// stall ingestion.
+type QueueConfig struct {
+	// Size is the per-forwarder ring buffer capacity. Default 50000.
+	Size int `json:"size" yaml:"size"`
+	// BatchFlushInterval bounds how long an entry can sit in the buffer before
+	// being flushed, even if BatchSize hasn't been reached. Default 2s.
+	BatchFlushInterval time.Duration `json:"batch_flush_interval" yaml:"batch_flush_interval"`
+	// DropPolicy controls what happens when the buffer is full: "reject" (the
+	// HTTP caller gets a 503, a gRPC caller a SubmitAck with accepted=false) or "drop_oldest" (the
+	// oldest buffered entry is evicted to make room). Default "reject".
+	DropPolicy string `json:"drop_policy" yaml:"drop_policy"`
+	// DLQDir is the directory dead-lettered batches are appended to as rotated,
+	// append-only NDJSON files. Default "dlq".
+	DLQDir string `json:"dlq_dir" yaml:"dlq_dir"`
+	// DLQMaxFileBytes rotates a forwarder's DLQ file once it crosses this size.
+	// Default 100MiB.
+	DLQMaxFileBytes int64 `json:"dlq_max_file_bytes" yaml:"dlq_max_file_bytes"`
+}
+
+// ForwarderConfig describes a single forwarder entry. Which of the type-specific
+// option fields are honored depends on Type.
+type ForwarderConfig struct {
+	Type      string        `json:"type" yaml:"type"`
+	Endpoint  string        `json:"endpoint" yaml:"endpoint"`
+	TLS       TLSConfig     `json:"tls" yaml:"tls"`
+	Auth      AuthConfig    `json:"auth" yaml:"auth"`
+	Path      string        `json:"path" yaml:"path"`   // used by the file forwarder
+	Topic     string        `json:"topic" yaml:"topic"` // used by the kafka forwarder
+	BatchSize int           `json:"batch_size" yaml:"batch_size"`
+	Timeout   time.Duration `json:"timeout" yaml:"timeout"`
+	Retry     RetryConfig   `json:"retry" yaml:"retry"`
+}
+
+type TLSConfig struct {
+	Enabled            bool   `json:"enabled" yaml:"enabled"`
+	CACertPath         string `json:"ca_cert_path" yaml:"ca_cert_path"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+}
+
+type AuthConfig struct {
+	Type     string `json:"type" yaml:"type"` // "none", "basic", "bearer"
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	Token    string `json:"token" yaml:"token"`
+}
+
+type RetryConfig struct {
+	MaxAttempts int           `json:"max_attempts" yaml:"max_attempts"`
+	BaseBackoff time.Duration `json:"base_backoff" yaml:"base_backoff"`
+	MaxBackoff  time.Duration `json:"max_backoff" yaml:"max_backoff"`
+}
+
+// PIIConfig controls which fields get hashed before logs leave the collector.
+type PIIConfig struct {
+	HashFields []string `json:"hash_fields" yaml:"hash_fields"`
+}
+
+// TenantOverride replaces the global alert thresholds for one tenant in the
+// per-log CheckForAlerts checks. The override is used as a whole: fields it
+// leaves unset (e.g. high_privilege_actions) are empty for that tenant rather
+// than inherited from the global AlertThresholds. The AlertEvaluator compares
+// every tenant against the global DenialRateThreshold; overrides do not
+// affect it.
+type TenantOverride struct {
+	AlertThresholds *AlertConfig `json:"alert_thresholds,omitempty" yaml:"alert_thresholds,omitempty"`
+}
+
+// AlertConfig configures both the legacy per-log heuristics and the
+// Prometheus-backed AlertEvaluator.
+type AlertConfig struct {
+	DenialRateThreshold   float64  `json:"denial_rate_threshold" yaml:"denial_rate_threshold"`
+	SuspiciousUserActions int      `json:"suspicious_user_actions" yaml:"suspicious_user_actions"`
+	HighPrivilegeActions  []string `json:"high_privilege_actions" yaml:"high_privilege_actions"`
+
+	PrometheusEndpoint   string        `json:"prometheus_endpoint" yaml:"prometheus_endpoint"`
+	AlertmanagerEndpoint string        `json:"alertmanager_endpoint" yaml:"alertmanager_endpoint"`
+	EvaluationInterval   time.Duration `json:"evaluation_interval" yaml:"evaluation_interval"`
+	ConsecutiveToFire    int           `json:"consecutive_to_fire" yaml:"consecutive_to_fire"`
+	ConsecutiveToResolve int           `json:"consecutive_to_resolve" yaml:"consecutive_to_resolve"`
+}
+
+// DefaultConfig returns the baked-in defaults, applied for any field the config
+// file leaves zero-valued.
+func DefaultConfig() Config {
+	return Config{
+		RetentionDays: 90,
+		BatchSize:     100,
+		Queue: QueueConfig{
+			Size:               50000,
+			BatchFlushInterval: 2 * time.Second,
+			DropPolicy:         DropPolicyReject,
+			DLQDir:             "dlq",
+			DLQMaxFileBytes:    100 * 1024 * 1024,
+		},
+		AlertThresholds: AlertConfig{
+			DenialRateThreshold:  0.1,
+			HighPrivilegeActions: []string{"delete", "admin", "manage"},
+			AlertmanagerEndpoint: "http://alertmanager:9093",
+			EvaluationInterval:   30 * time.Second,
+			ConsecutiveToFire:    3,
+			ConsecutiveToResolve: 3,
+		},
+	}
+}
+
+// LoadConfig reads and parses a YAML or JSON config file (by extension) and
+// validates every forwarder's type, returning ErrInvalidConfig for anything
+// unrecognized.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	cfg := DefaultConfig()
+
+	switch filepath.Ext(path) {
+	case ".json":
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML config %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported config extension %q", ErrInvalidConfig, filepath.Ext(path))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that every forwarder declares a known type. It's also what
+// backs the POST /v1/config dry-run endpoint.
+func (c *Config) Validate() error {
+	for _, fc := range c.Forwarders {
+		switch fc.Type {
+		case "loki", "elasticsearch", "kafka", "file", "http":
+			// known
+		default:
+			return fmt.Errorf("%w: unknown forwarder type %q", ErrInvalidConfig, fc.Type)
+		}
+	}
+
+	switch c.Queue.DropPolicy {
+	case "", DropPolicyReject, DropPolicyDropOldest:
+		// known
+	default:
+		return fmt.Errorf("%w: unknown queue drop_policy %q", ErrInvalidConfig, c.Queue.DropPolicy)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the config with credentials and tokens blanked out,
+// safe to return from GET /v1/config.
+func (c *Config) Redacted() Config {
+	redacted := *c
+	redacted.Forwarders = make([]ForwarderConfig, len(c.Forwarders))
+	for i, fc := range c.Forwarders {
+		if fc.Auth.Password != "" {
+			fc.Auth.Password = "[redacted]"
+		}
+		if fc.Auth.Token != "" {
+			fc.Auth.Token = "[redacted]"
+		}
+		redacted.Forwarders[i] = fc
+	}
+	return redacted
+}
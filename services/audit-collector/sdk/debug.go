@@ -0,0 +1,75 @@
+package sdk
+
+import "net/url"
+
+// RedactEndpoint strips any embedded userinfo (user:password@) from a
+// forwarder endpoint before it's exposed via /debug/forwarders, the same way
+// Config.Redacted blanks out auth credentials for GET /v1/config. Endpoints
+// that aren't URLs (e.g. a file path) are returned unchanged.
+func RedactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.User == nil {
+		return endpoint
+	}
+	u.User = nil
+	return u.String()
+}
+
+// recordDebug serializes calls into ac.recent.record on ac.recentMu, since
+// record assumes a single writer: HandleBatch and ProcessStructured can run
+// concurrently across HTTP and gRPC requests. RecentDecisions reads the ring
+// without taking ac.recentMu.
+func (ac *AuditCollector) recordDebug(decisionLog DecisionLog) {
+	ac.recentMu.Lock()
+	ac.recent.record(decisionLog)
+	ac.recentMu.Unlock()
+
+	ac.policyStats.record(decisionLog)
+}
+
+// ForwarderStatus is the /debug/forwarders view of one forwarder's health.
+type ForwarderStatus = Status
+
+// ForwarderStatuses returns a health snapshot of every active forwarder.
+func (ac *AuditCollector) ForwarderStatuses() []ForwarderStatus {
+	queues := ac.activeQueues()
+	statuses := make([]ForwarderStatus, 0, len(queues))
+	for _, q := range queues {
+		statuses = append(statuses, q.Status())
+	}
+	return statuses
+}
+
+// RecentDecisionsFilter narrows a RecentDecisions query. A zero-value field
+// matches any value.
+type RecentDecisionsFilter struct {
+	Tenant   string
+	Decision string // "allow" or "deny"
+	Limit    int
+}
+
+// RecentDecisions returns up to filter.Limit of the most recently processed
+// decision logs matching filter, newest first. Whatever fields
+// Config.PIIHashing.HashFields named were already hashed by
+// ValidateAndEnrichLog before the entry was recorded; fields not listed there
+// come through as-is, so an empty hash_fields config means this is raw.
+func (ac *AuditCollector) RecentDecisions(filter RecentDecisionsFilter) []DecisionLog {
+	return ac.recent.snapshot(filter.Limit, func(d DecisionLog) bool {
+		if filter.Tenant != "" && d.User.Tenant != filter.Tenant {
+			return false
+		}
+		switch filter.Decision {
+		case "allow":
+			return d.Decision.Allow
+		case "deny":
+			return !d.Decision.Allow
+		}
+		return true
+	})
+}
+
+// PolicyStats returns the aggregated per-policy decision counts observed in
+// the last hour.
+func (ac *AuditCollector) PolicyStats() []PolicyStat {
+	return ac.policyStats.Snapshot()
+}
@@ -0,0 +1,214 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// DLQ is an append-only, size-rotated NDJSON dead-letter queue for a single
+// forwarder. Batches that exhaust their retries are spilled here so they can be
+// replayed later via POST /v1/dlq/replay instead of being lost.
+type DLQ struct {
+	dir           string
+	forwarderName string
+	maxFileBytes  int64
+
+	mu          sync.Mutex
+	file        *os.File
+	currentSize int64
+	segment     int
+}
+
+// NewDLQ ensures dir exists and returns a DLQ that appends rotated segment files
+// named "<forwarderName>.<segment>.ndjson" under it.
+func NewDLQ(dir, forwarderName string, maxFileBytes int64) (*DLQ, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create DLQ dir %s: %w", dir, err)
+	}
+	if maxFileBytes <= 0 {
+		maxFileBytes = 100 * 1024 * 1024
+	}
+
+	d := &DLQ{dir: dir, forwarderName: forwarderName, maxFileBytes: maxFileBytes}
+	d.resumeSegment()
+	if err := d.openSegment(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *DLQ) segmentPath(segment int) string {
+	return filepath.Join(d.dir, fmt.Sprintf("%s.%05d.ndjson", d.forwarderName, segment))
+}
+
+// resumeSegment picks the segment openSegment should open on construction:
+// the highest existing one if it is still under maxFileBytes, otherwise the
+// number after it (0 when no segment exists). Only called from NewDLQ - Append's
+// rotation already knows the segment to open and must not rescan (see rotateSegment).
+func (d *DLQ) resumeSegment() {
+	highest := -1
+	segments, _ := d.listSegments()
+	for _, path := range segments {
+		var n int
+		if _, err := fmt.Sscanf(filepath.Base(path)[len(d.forwarderName)+1:], "%d.ndjson", &n); err == nil && n > highest {
+			highest = n
+		}
+	}
+	d.segment = highest + 1
+	if highest < 0 {
+		return
+	}
+	if info, err := os.Stat(d.segmentPath(highest)); err == nil && info.Size() < d.maxFileBytes {
+		d.segment = highest
+		d.currentSize = info.Size()
+	}
+}
+
+func (d *DLQ) openSegment() error {
+	f, err := os.OpenFile(d.segmentPath(d.segment), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open DLQ segment %s: %w", d.segmentPath(d.segment), err)
+	}
+	d.file = f
+	return nil
+}
+
+// rotateSegment closes the current segment file and opens a fresh one at the
+// next segment number. Unlike resumeSegment, it never rescans the directory:
+// segment numbers only go up from the highest existing one, so the new
+// segment does not exist yet.
+func (d *DLQ) rotateSegment() error {
+	if err := d.file.Close(); err != nil {
+		return err
+	}
+	d.segment++
+	d.currentSize = 0
+	return d.openSegment()
+}
+
+// Append writes logs as NDJSON, rotating to a new segment if the current one
+// would exceed maxFileBytes.
+func (d *DLQ) Append(logs []DecisionLog) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, log := range logs {
+		line, err := json.Marshal(log)
+		if err != nil {
+			return fmt.Errorf("failed to marshal DLQ entry: %w", err)
+		}
+		line = append(line, '\n')
+
+		if d.currentSize+int64(len(line)) > d.maxFileBytes {
+			if err := d.rotateSegment(); err != nil {
+				return err
+			}
+		}
+
+		n, err := d.file.Write(line)
+		if err != nil {
+			return fmt.Errorf("failed to write DLQ entry: %w", err)
+		}
+		d.currentSize += int64(n)
+	}
+
+	return nil
+}
+
+// Replay reads every rotated (non-active) segment in order, forwards each in
+// BatchSize-sized chunks via forward, and deletes a segment once every entry in
+// it has been forwarded successfully. It stops and returns the error (and the
+// count replayed so far) on the first forwarding failure, leaving the
+// remaining entries queued for a future replay.
+//
+// The active segment - the one Append is currently writing to - is never
+// replayed: it's the only segment that isn't provably immutable, so skipping
+// it lets Replay take d.mu just long enough to snapshot the segment list and
+// then run every read and forward() call (network I/O, for a real forwarder)
+// without holding it, instead of blocking Append for the whole replay. The
+// active segment is picked up on a later replay once it has rotated.
+func (d *DLQ) Replay(ctx context.Context, batchSize int, forward func(context.Context, []DecisionLog) error) (int, error) {
+	d.mu.Lock()
+	segments, err := d.listSegments()
+	activeSegment := d.segmentPath(d.segment)
+	d.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	replayed := 0
+	for _, path := range segments {
+		if path == activeSegment {
+			continue
+		}
+
+		logs, err := readNDJSON(path)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to read DLQ segment %s: %w", path, err)
+		}
+
+		for start := 0; start < len(logs); start += batchSize {
+			end := start + batchSize
+			if end > len(logs) {
+				end = len(logs)
+			}
+			if err := forward(ctx, logs[start:end]); err != nil {
+				return replayed, fmt.Errorf("failed to replay DLQ segment %s: %w", path, err)
+			}
+			replayed += end - start
+		}
+
+		if err := os.Remove(path); err != nil {
+			return replayed, fmt.Errorf("failed to remove replayed DLQ segment %s: %w", path, err)
+		}
+	}
+
+	return replayed, nil
+}
+
+func (d *DLQ) listSegments() ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DLQ dir %s: %w", d.dir, err)
+	}
+
+	prefix := d.forwarderName + "."
+	var segments []string
+	for _, e := range entries {
+		if !e.IsDir() && len(e.Name()) > len(prefix) && e.Name()[:len(prefix)] == prefix {
+			segments = append(segments, filepath.Join(d.dir, e.Name()))
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+func readNDJSON(path string) ([]DecisionLog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var logs []DecisionLog
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var log DecisionLog
+		if err := json.Unmarshal(scanner.Bytes(), &log); err != nil {
+			return nil, fmt.Errorf("malformed DLQ entry: %w", err)
+		}
+		logs = append(logs, log)
+	}
+	return logs, scanner.Err()
+}
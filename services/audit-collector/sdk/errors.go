@@ -0,0 +1,9 @@
+package sdk
+
+import "errors"
+
+// ErrInvalidConfig is returned (wrapped with details via %w) when a config
+// names an unknown forwarder type or queue drop_policy, or a config file has an
+// unsupported extension. A file that fails to parse as JSON or YAML returns a
+// plain parse error, not ErrInvalidConfig.
+var ErrInvalidConfig = errors.New("invalid audit collector config")
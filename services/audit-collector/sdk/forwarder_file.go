@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileForwarder appends each log as a line of NDJSON to a local file. Mostly
+// useful for local development and as the on-disk format the dead-letter queue
+// also uses.
+type FileForwarder struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileForwarder(path string) (*FileForwarder, error) {
+	if path == "" {
+		path = "audit-decisions.ndjson"
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	return &FileForwarder{path: path, file: f}, nil
+}
+
+func (f *FileForwarder) GetName() string {
+	return "file"
+}
+
+func (f *FileForwarder) Endpoint() string {
+	return f.path
+}
+
+// Close closes the underlying file. Called when a config reload retires this
+// forwarder, so the old fd doesn't leak for the lifetime of the process.
+func (f *FileForwarder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+func (f *FileForwarder) ForwardLog(ctx context.Context, log DecisionLog) error {
+	return f.ForwardBatch(ctx, []DecisionLog{log})
+}
+
+func (f *FileForwarder) ForwardBatch(ctx context.Context, logs []DecisionLog) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, log := range logs {
+		line, err := json.Marshal(log)
+		if err != nil {
+			return fmt.Errorf("failed to marshal decision log: %w", err)
+		}
+		if _, err := f.file.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write decision log: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPForwarder POSTs the raw batch as a JSON array to an arbitrary webhook-style
+// endpoint, for destinations that don't warrant their own forwarder type.
+type HTTPForwarder struct {
+	endpoint string
+	client   *http.Client
+	auth     AuthConfig
+}
+
+func NewHTTPForwarder(endpoint string, timeout time.Duration, auth AuthConfig, tlsCfg TLSConfig) (*HTTPForwarder, error) {
+	client, err := newHTTPClient(tlsCfg, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPForwarder{
+		endpoint: endpoint,
+		client:   client,
+		auth:     auth,
+	}, nil
+}
+
+func (h *HTTPForwarder) GetName() string {
+	return "http"
+}
+
+func (h *HTTPForwarder) Endpoint() string {
+	return h.endpoint
+}
+
+func (h *HTTPForwarder) ForwardLog(ctx context.Context, log DecisionLog) error {
+	return h.ForwardBatch(ctx, []DecisionLog{log})
+}
+
+func (h *HTTPForwarder) ForwardBatch(ctx context.Context, logs []DecisionLog) error {
+	body, err := json.Marshal(logs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal decision logs: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	h.applyAuth(req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("http forwarder endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (h *HTTPForwarder) applyAuth(req *http.Request) {
+	switch h.auth.Type {
+	case "basic":
+		req.SetBasicAuth(h.auth.Username, h.auth.Password)
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+h.auth.Token)
+	}
+}
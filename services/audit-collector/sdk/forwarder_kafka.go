@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaForwarder publishes each decision log as a JSON message keyed by
+// decision_id, so per-tenant consumers can partition on user.tenant downstream
+// via a custom partitioner if needed.
+type KafkaForwarder struct {
+	brokerEndpoint string
+	writer         *kafka.Writer
+}
+
+func NewKafkaForwarder(brokerEndpoint, topic string) *KafkaForwarder {
+	return &KafkaForwarder{
+		brokerEndpoint: brokerEndpoint,
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokerEndpoint),
+			Topic:    topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+	}
+}
+
+func (k *KafkaForwarder) GetName() string {
+	return "kafka"
+}
+
+func (k *KafkaForwarder) Endpoint() string {
+	return k.brokerEndpoint
+}
+
+// Close closes the underlying Kafka writer and its connections. Called when a
+// config reload retires this forwarder.
+func (k *KafkaForwarder) Close() error {
+	return k.writer.Close()
+}
+
+func (k *KafkaForwarder) ForwardLog(ctx context.Context, log DecisionLog) error {
+	return k.ForwardBatch(ctx, []DecisionLog{log})
+}
+
+func (k *KafkaForwarder) ForwardBatch(ctx context.Context, logs []DecisionLog) error {
+	messages := make([]kafka.Message, 0, len(logs))
+
+	for _, log := range logs {
+		value, err := json.Marshal(log)
+		if err != nil {
+			return fmt.Errorf("failed to marshal decision log: %w", err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(log.DecisionID),
+			Value: value,
+		})
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return k.writer.WriteMessages(ctx, messages...)
+}
@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// BuildForwarders constructs one LogForwarder per entry in cfgs, in order.
+// Unknown types are rejected by Config.Validate before this is ever called, but
+// it re-checks so a hand-built []ForwarderConfig that never went through
+// Validate can't slip an invalid type through.
+func BuildForwarders(cfgs []ForwarderConfig) ([]LogForwarder, error) {
+	forwarders := make([]LogForwarder, 0, len(cfgs))
+
+	for _, fc := range cfgs {
+		timeout := fc.Timeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+
+		switch fc.Type {
+		case "loki":
+			fwd, err := NewLokiForwarder(fc.Endpoint, timeout, fc.TLS)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build loki forwarder: %w", err)
+			}
+			forwarders = append(forwarders, fwd)
+		case "elasticsearch":
+			fwd, err := NewElasticsearchForwarder(fc.Endpoint, timeout, fc.TLS)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build elasticsearch forwarder: %w", err)
+			}
+			forwarders = append(forwarders, fwd)
+		case "kafka":
+			forwarders = append(forwarders, NewKafkaForwarder(fc.Endpoint, fc.Topic))
+		case "file":
+			fwd, err := NewFileForwarder(fc.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build file forwarder: %w", err)
+			}
+			forwarders = append(forwarders, fwd)
+		case "http":
+			fwd, err := NewHTTPForwarder(fc.Endpoint, timeout, fc.Auth, fc.TLS)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build http forwarder: %w", err)
+			}
+			forwarders = append(forwarders, fwd)
+		default:
+			return nil, fmt.Errorf("%w: unknown forwarder type %q", ErrInvalidConfig, fc.Type)
+		}
+	}
+
+	return forwarders, nil
+}
@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// hashPII returns a stable, non-reversible hex digest of s. Used to redact
+// fields named in Config.PIIHashing.HashFields before a decision log is
+// forwarded or exposed via /debug/recent. Empty strings are left alone so an
+// absent field doesn't turn into a misleading hash of "".
+func hashPII(s string) string {
+	if s == "" {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// applyPIIHashing hashes every field named in fields on log, in place.
+// Unknown field names are ignored rather than rejected, so a typo in a
+// config's hash_fields doesn't break ingestion.
+func applyPIIHashing(log *DecisionLog, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case "user.id":
+			log.User.ID = hashPII(log.User.ID)
+		case "user.username":
+			log.User.Username = hashPII(log.User.Username)
+		case "user.email":
+			log.User.Email = hashPII(log.User.Email)
+		case "user.session_id":
+			log.User.SessionID = hashPII(log.User.SessionID)
+		case "request.source_ip":
+			log.Request.SourceIP = hashPII(log.Request.SourceIP)
+		case "request.resource_id":
+			log.Request.ResourceID = hashPII(log.Request.ResourceID)
+		}
+	}
+}
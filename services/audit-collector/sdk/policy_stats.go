@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// policyStatsWindow is the span /debug/policies aggregates over.
+const policyStatsWindow = time.Hour
+
+// policyStatsBuckets is the resolution of the sliding window: one bucket per
+// minute, so entries age out in roughly one-minute increments rather than all
+// falling off a single hourly cliff at once.
+const policyStatsBuckets = 60
+
+// PolicyStat is the aggregated view of one policy_id/policy_version pair
+// observed within the last hour, returned by /debug/policies.
+type PolicyStat struct {
+	PolicyID      string
+	PolicyVersion string
+	Total         int64
+	Allowed       int64
+	Denied        int64
+}
+
+type policyKey struct {
+	policyID      string
+	policyVersion string
+}
+
+type policyBucket struct {
+	start  time.Time
+	counts map[policyKey]*PolicyStat
+}
+
+// policyStatsTracker keeps a sliding one-hour window of per-policy decision
+// counts, bucketed by minute so old activity ages out instead of being kept
+// forever.
+type policyStatsTracker struct {
+	mu      sync.Mutex
+	buckets []policyBucket
+}
+
+func newPolicyStatsTracker() *policyStatsTracker {
+	return &policyStatsTracker{
+		buckets: make([]policyBucket, policyStatsBuckets),
+	}
+}
+
+func bucketStart(t time.Time) time.Time {
+	return t.Truncate(time.Minute)
+}
+
+// record tallies one decision log against its policy_id/policy_version in
+// the current minute's bucket, evicting any bucket that has aged out of the
+// window.
+func (t *policyStatsTracker) record(decisionLog DecisionLog) {
+	now := bucketStart(time.Now())
+	idx := int(now.Unix()/60) % policyStatsBuckets
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := &t.buckets[idx]
+	if !b.start.Equal(now) {
+		b.start = now
+		b.counts = make(map[policyKey]*PolicyStat)
+	}
+
+	key := policyKey{policyID: decisionLog.Decision.PolicyID, policyVersion: decisionLog.Decision.PolicyVersion}
+	stat, ok := b.counts[key]
+	if !ok {
+		stat = &PolicyStat{PolicyID: key.policyID, PolicyVersion: key.policyVersion}
+		b.counts[key] = stat
+	}
+
+	stat.Total++
+	if decisionLog.Decision.Allow {
+		stat.Allowed++
+	} else {
+		stat.Denied++
+	}
+}
+
+// Snapshot returns the aggregated per-policy counts across all buckets whose
+// start time still falls within the last hour.
+func (t *policyStatsTracker) Snapshot() []PolicyStat {
+	cutoff := time.Now().Add(-policyStatsWindow)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	merged := make(map[policyKey]*PolicyStat)
+	for _, b := range t.buckets {
+		if b.start.Before(cutoff) {
+			continue
+		}
+		for key, stat := range b.counts {
+			out, ok := merged[key]
+			if !ok {
+				out = &PolicyStat{PolicyID: key.policyID, PolicyVersion: key.policyVersion}
+				merged[key] = out
+			}
+			out.Total += stat.Total
+			out.Allowed += stat.Allowed
+			out.Denied += stat.Denied
+		}
+	}
+
+	stats := make([]PolicyStat, 0, len(merged))
+	for _, stat := range merged {
+		stats = append(stats, *stat)
+	}
+	return stats
+}
@@ -0,0 +1,347 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DropPolicy values for QueueConfig.DropPolicy.
+const (
+	DropPolicyReject     = "reject"
+	DropPolicyDropOldest = "drop_oldest"
+)
+
+// ErrQueueSaturated is returned by Enqueue when DropPolicy is "reject" and a
+// forwarder's buffer is full. It surfaces as HTTP 503, and over gRPC as a
+// SubmitAck with accepted=false.
+var ErrQueueSaturated = errors.New("audit queue saturated")
+
+// ErrQueueStopped is returned by Enqueue once Stop has been called on the
+// queue, e.g. because a config reload retired this forwarder. Callers treat
+// it the same as ErrQueueSaturated: reject this entry on this queue rather
+// than panic sending on a closed channel.
+var ErrQueueStopped = errors.New("audit queue stopped")
+
+const (
+	retryBaseBackoff = 100 * time.Millisecond
+	retryMaxBackoff  = 30 * time.Second
+	retryMaxAttempts = 5
+)
+
+var (
+	queueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "audit_queue_depth",
+			Help: "Number of decision logs currently buffered for a forwarder",
+		},
+		[]string{"forwarder"},
+	)
+
+	queueDropsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "audit_queue_drops_total",
+			Help: "Total number of decision logs dropped instead of being queued for a forwarder",
+		},
+		[]string{"forwarder", "reason"},
+	)
+
+	retryAttemptsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "audit_retry_attempts_total",
+			Help: "Total number of ForwardBatch retry attempts for a forwarder",
+		},
+		[]string{"forwarder"},
+	)
+
+	dlqEntriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "audit_dlq_entries_total",
+			Help: "Total number of decision logs spilled to a forwarder's dead-letter queue",
+		},
+		[]string{"forwarder"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(queueDepth)
+	prometheus.MustRegister(queueDropsTotal)
+	prometheus.MustRegister(retryAttemptsTotal)
+	prometheus.MustRegister(dlqEntriesTotal)
+}
+
+// ForwarderQueue decouples a LogForwarder from the ingestion path: entries are
+// buffered in a bounded channel and flushed to the forwarder in batches by a
+// dedicated worker goroutine, with retries and a disk-backed DLQ for batches that
+// never make it through.
+type ForwarderQueue struct {
+	name       string
+	forwarder  LogForwarder
+	buffer     chan DecisionLog
+	batchSize  int
+	flushEvery time.Duration
+	timeout    time.Duration
+	retry      RetryConfig
+	dlq        *DLQ
+	dropPolicy string
+
+	done chan struct{}
+
+	// lifecycleMu serializes Stop's close(buffer) against in-flight Enqueue
+	// sends, so a reload retiring this queue can't race a concurrent request
+	// into a send-on-closed-channel panic.
+	lifecycleMu sync.RWMutex
+	closed      bool
+
+	statusMu    sync.RWMutex
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	inFlight    int32
+}
+
+// Status is a point-in-time snapshot of a ForwarderQueue, used by
+// /debug/forwarders.
+type Status struct {
+	Name              string
+	Endpoint          string
+	QueueDepth        int
+	InFlightBatchSize int
+	LastSuccess       time.Time
+	LastFailure       time.Time
+	LastError         string
+}
+
+// Status returns a snapshot of this queue's current health.
+func (q *ForwarderQueue) Status() Status {
+	q.statusMu.RLock()
+	defer q.statusMu.RUnlock()
+
+	return Status{
+		Name:              q.name,
+		Endpoint:          q.forwarder.Endpoint(),
+		QueueDepth:        len(q.buffer),
+		InFlightBatchSize: int(atomic.LoadInt32(&q.inFlight)),
+		LastSuccess:       q.lastSuccess,
+		LastFailure:       q.lastFailure,
+		LastError:         q.lastError,
+	}
+}
+
+// NewForwarderQueue builds (but does not start) a queue in front of forwarder.
+func NewForwarderQueue(forwarder LogForwarder, batchSize int, qcfg QueueConfig, fcfg ForwarderConfig) (*ForwarderQueue, error) {
+	size := qcfg.Size
+	if size <= 0 {
+		size = 50000
+	}
+	flushEvery := qcfg.BatchFlushInterval
+	if flushEvery <= 0 {
+		flushEvery = 2 * time.Second
+	}
+	timeout := fcfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	dropPolicy := qcfg.DropPolicy
+	if dropPolicy == "" {
+		dropPolicy = DropPolicyReject
+	}
+	dlqDir := qcfg.DLQDir
+	if dlqDir == "" {
+		dlqDir = "dlq"
+	}
+
+	dlq, err := NewDLQ(dlqDir, forwarder.GetName(), qcfg.DLQMaxFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForwarderQueue{
+		name:       forwarder.GetName(),
+		forwarder:  forwarder,
+		buffer:     make(chan DecisionLog, size),
+		batchSize:  batchSize,
+		flushEvery: flushEvery,
+		timeout:    timeout,
+		retry:      fcfg.Retry,
+		dlq:        dlq,
+		dropPolicy: dropPolicy,
+		done:       make(chan struct{}),
+	}, nil
+}
+
+// Start runs the batching worker until ctx is cancelled or Stop is called.
+func (q *ForwarderQueue) Start(ctx context.Context) {
+	go q.run(ctx)
+}
+
+// Stop closes the worker and blocks until it has exited, flushing whatever is
+// left in the buffer first. Safe to call more than once.
+func (q *ForwarderQueue) Stop() {
+	q.lifecycleMu.Lock()
+	if q.closed {
+		q.lifecycleMu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.buffer)
+	q.lifecycleMu.Unlock()
+
+	<-q.done
+}
+
+// Enqueue buffers a single decision log for forwarding. It returns
+// ErrQueueSaturated if the buffer is full and DropPolicy is "reject"; under
+// "drop_oldest" it evicts the oldest buffered entry to make room instead. It
+// returns ErrQueueStopped if Stop has already been called on this queue.
+func (q *ForwarderQueue) Enqueue(entry DecisionLog) error {
+	q.lifecycleMu.RLock()
+	defer q.lifecycleMu.RUnlock()
+
+	if q.closed {
+		return ErrQueueStopped
+	}
+
+	select {
+	case q.buffer <- entry:
+		queueDepth.WithLabelValues(q.name).Set(float64(len(q.buffer)))
+		return nil
+	default:
+	}
+
+	if q.dropPolicy == DropPolicyDropOldest {
+		select {
+		case <-q.buffer:
+			queueDropsTotal.WithLabelValues(q.name, "drop_oldest").Inc()
+		default:
+		}
+		select {
+		case q.buffer <- entry:
+			queueDepth.WithLabelValues(q.name).Set(float64(len(q.buffer)))
+			return nil
+		default:
+			queueDropsTotal.WithLabelValues(q.name, "full").Inc()
+			return ErrQueueSaturated
+		}
+	}
+
+	queueDropsTotal.WithLabelValues(q.name, "full").Inc()
+	return ErrQueueSaturated
+}
+
+func (q *ForwarderQueue) run(ctx context.Context) {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.flushEvery)
+	defer ticker.Stop()
+
+	batch := make([]DecisionLog, 0, q.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		q.flush(ctx, batch)
+		batch = make([]DecisionLog, 0, q.batchSize)
+	}
+
+	for {
+		select {
+		case entry, ok := <-q.buffer:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, entry)
+			queueDepth.WithLabelValues(q.name).Set(float64(len(q.buffer)))
+			if len(batch) >= q.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			flush()
+			return
+		}
+	}
+}
+
+// flush sends batch to the forwarder, retrying with exponential backoff and
+// jitter, and spills it to the DLQ once retries are exhausted.
+func (q *ForwarderQueue) flush(ctx context.Context, batch []DecisionLog) {
+	atomic.StoreInt32(&q.inFlight, int32(len(batch)))
+	defer atomic.StoreInt32(&q.inFlight, 0)
+
+	if err := q.forwardWithRetry(ctx, batch); err != nil {
+		q.statusMu.Lock()
+		q.lastFailure = time.Now()
+		q.lastError = err.Error()
+		q.statusMu.Unlock()
+
+		log.Printf("ForwarderQueue[%s]: giving up after retries, spilling %d entries to DLQ: %v", q.name, len(batch), err)
+		if err := q.dlq.Append(batch); err != nil {
+			log.Printf("ForwarderQueue[%s]: failed to spill to DLQ: %v", q.name, err)
+			return
+		}
+		dlqEntriesTotal.WithLabelValues(q.name).Add(float64(len(batch)))
+		return
+	}
+
+	q.statusMu.Lock()
+	q.lastSuccess = time.Now()
+	q.statusMu.Unlock()
+}
+
+func (q *ForwarderQueue) forwardWithRetry(ctx context.Context, batch []DecisionLog) error {
+	maxAttempts := q.retry.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = retryMaxAttempts
+	}
+	base := q.retry.BaseBackoff
+	if base <= 0 {
+		base = retryBaseBackoff
+	}
+	maxBackoff := q.retry.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = retryMaxBackoff
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			retryAttemptsTotal.WithLabelValues(q.name).Inc()
+			backoff := expBackoffWithJitter(base, maxBackoff, attempt)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, q.timeout)
+		lastErr = q.forwarder.ForwardBatch(callCtx, batch)
+		cancel()
+
+		if lastErr == nil {
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+// expBackoffWithJitter returns base*2^(attempt-1) capped at max, plus up to 20%
+// jitter, so retrying forwarders don't all thunder-herd their downstream at once.
+func expBackoffWithJitter(base, maxBackoff time.Duration, attempt int) time.Duration {
+	backoff := base << uint(attempt-1)
+	if backoff <= 0 || backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	jitter := time.Duration(rand.Int63n(int64(backoff) / 5 + 1))
+	return backoff + jitter
+}
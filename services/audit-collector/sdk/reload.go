@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reloads a config file on write/rename events and invokes onReload with
+// the freshly parsed, validated Config. A config file that fails to parse or
+// validate is logged and left in place: the last-known-good Config keeps running.
+type Watcher struct {
+	fsWatcher *fsnotify.Watcher
+	path      string
+	onReload  func(*Config)
+}
+
+// NewWatcher starts watching path in the background and returns immediately.
+//
+// It watches path's parent directory rather than the file itself: an atomic
+// rename-and-replace (a Kubernetes ConfigMap's "..data" symlink swap, an
+// editor's save-and-replace) removes and recreates the watched file's inode,
+// which silently drops an inotify watch on the file directly — leaving every
+// later reload un-noticed. Directory watches survive that because the
+// directory's own inode never goes away.
+func NewWatcher(path string, onReload func(*Config)) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := fsWatcher.Add(dir); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	w := &Watcher{fsWatcher: fsWatcher, path: path, onReload: onReload}
+	go w.loop()
+	return w, nil
+}
+
+func (w *Watcher) loop() {
+	target := filepath.Base(w.path)
+
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			// The directory watch sees every file in it; only react to events
+			// for our config file (or, for a symlink-swap deployment, the
+			// "..data" entry Kubernetes repoints to apply a new ConfigMap).
+			name := filepath.Base(event.Name)
+			if name != target && name != "..data" {
+				continue
+			}
+
+			cfg, err := LoadConfig(w.path)
+			if err != nil {
+				log.Printf("Watcher: failed to reload %s: %v", w.path, err)
+				continue
+			}
+			w.onReload(cfg)
+
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Watcher: error watching %s: %v", w.path, err)
+		}
+	}
+}
+
+// Close stops the watcher.
+func (w *Watcher) Close() error {
+	return w.fsWatcher.Close()
+}
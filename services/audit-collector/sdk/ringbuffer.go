@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"sync/atomic"
+)
+
+// defaultRingSize is used when DEBUG_RING_SIZE is unset or invalid.
+const defaultRingSize = 10000
+
+// decisionRing is a fixed-size circular buffer of the most recently processed
+// DecisionLogs, for /debug/recent. It has a single writer (the ingestion
+// pipeline, via record) and supports any number of concurrent readers
+// (snapshot/query from the debug handlers).
+//
+// Each slot is guarded by its own sequence counter rather than a shared lock:
+// the writer bumps the sequence to an odd number, writes the slot, then bumps
+// it to an even number; a reader that observes an odd sequence, or a sequence
+// that changed while it copied the slot, knows it raced the writer and
+// retries. After three attempts that all race the writer, the reader skips
+// that slot (see readRingSlot).
+type decisionRing struct {
+	slots []ringSlot
+	size  uint64
+	next  uint64 // next write position, incremented by the single writer
+}
+
+type ringSlot struct {
+	seq   uint64
+	entry DecisionLog
+}
+
+// newDecisionRing builds a ring buffer with room for size entries. A size of
+// zero or less falls back to defaultRingSize.
+func newDecisionRing(size int) *decisionRing {
+	if size <= 0 {
+		size = defaultRingSize
+	}
+	return &decisionRing{
+		slots: make([]ringSlot, size),
+		size:  uint64(size),
+	}
+}
+
+// record appends entry as the newest decision log, overwriting the oldest
+// once the ring is full. Only safe to call from a single goroutine at a time.
+func (r *decisionRing) record(entry DecisionLog) {
+	idx := r.next % r.size
+	slot := &r.slots[idx]
+
+	seq := atomic.LoadUint64(&slot.seq)
+	atomic.StoreUint64(&slot.seq, seq+1) // odd: write in progress
+	slot.entry = entry
+	atomic.StoreUint64(&slot.seq, seq+2) // even: write complete
+
+	r.next++
+}
+
+// snapshot returns up to limit of the most recently recorded entries
+// matching filter, newest first. A nil filter matches everything.
+func (r *decisionRing) snapshot(limit int, filter func(DecisionLog) bool) []DecisionLog {
+	if limit <= 0 {
+		limit = int(r.size)
+	}
+
+	out := make([]DecisionLog, 0, limit)
+	next := r.next
+	for i := uint64(0); i < r.size && len(out) < limit; i++ {
+		// next+size-1-i stays non-negative for i in [0, size), avoiding
+		// uint64 underflow when next is still small (ring not yet full).
+		idx := (next + r.size - 1 - i) % r.size
+		slot := &r.slots[idx]
+
+		entry, ok := readRingSlot(slot)
+		if !ok {
+			continue
+		}
+		if filter != nil && !filter(entry) {
+			continue
+		}
+		out = append(out, entry)
+	}
+
+	return out
+}
+
+// readRingSlot copies a slot's entry if it isn't mid-write, retrying a
+// bounded number of times in case it races the writer.
+func readRingSlot(slot *ringSlot) (DecisionLog, bool) {
+	for attempt := 0; attempt < 3; attempt++ {
+		seq1 := atomic.LoadUint64(&slot.seq)
+		if seq1 == 0 {
+			return DecisionLog{}, false // never written
+		}
+		if seq1%2 == 1 {
+			continue // write in progress
+		}
+		entry := slot.entry
+		seq2 := atomic.LoadUint64(&slot.seq)
+		if seq1 == seq2 {
+			return entry, true
+		}
+	}
+	return DecisionLog{}, false
+}
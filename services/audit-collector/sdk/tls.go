@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// newHTTPClient builds an *http.Client for an HTTP-based forwarder (loki,
+// elasticsearch, http) honoring its TLSConfig: a custom CA bundle for
+// internally-issued certs, and/or InsecureSkipVerify for test/staging
+// endpoints. With TLS disabled (the default) it returns a plain client,
+// matching the forwarders' pre-TLSConfig behavior.
+func newHTTPClient(cfg TLSConfig, timeout time.Duration) (*http.Client, error) {
+	if !cfg.Enabled {
+		return &http.Client{Timeout: timeout}, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CACertPath != "" {
+		pem, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_path %s: %w", cfg.CACertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca_cert_path %s", cfg.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}